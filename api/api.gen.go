@@ -1,6 +1,6 @@
 // Package api provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/deepmap/oapi-codegen version v1.11.1-0.20220912230023-4a1477f6a8ba DO NOT EDIT.
+// Code generated by github.com/deepmap/oapi-codegen version v1.12.4 DO NOT EDIT.
 package api
 
 import (
@@ -10,25 +10,79 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
 )
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List projects.
+	// (GET /projects)
+	ListProjects(w http.ResponseWriter, r *http.Request, params ListProjectsParams)
+	// Delete a project.
+	// (DELETE /projects/{name})
+	DeleteProject(w http.ResponseWriter, r *http.Request, name string)
 	// Retrieve a project by name.
-	// (GET /project)
-	GetProject(w http.ResponseWriter, r *http.Request, params GetProjectParams)
+	// (GET /projects/{name})
+	GetProject(w http.ResponseWriter, r *http.Request, name string)
 	// Create or update a project's metadata.
-	// (POST /project)
-	PostProject(w http.ResponseWriter, r *http.Request)
+	// (PUT /projects/{name})
+	PutProject(w http.ResponseWriter, r *http.Request, name string)
+	// List tasks.
+	// (GET /tasks)
+	ListTasks(w http.ResponseWriter, r *http.Request, params ListTasksParams)
+	// Create a task.
+	// (POST /tasks)
+	CreateTask(w http.ResponseWriter, r *http.Request)
+	// Count tasks.
+	// (GET /tasks/count)
+	CountTasks(w http.ResponseWriter, r *http.Request)
+	// List overdue tasks.
+	// (GET /tasks/overdue)
+	ListOverdueTasks(w http.ResponseWriter, r *http.Request, params ListOverdueTasksParams)
+	// Search tasks.
+	// (GET /tasks/search)
+	SearchTasks(w http.ResponseWriter, r *http.Request, params SearchTasksParams)
+	// List upcoming tasks.
+	// (GET /tasks/upcoming)
+	ListUpcomingTasks(w http.ResponseWriter, r *http.Request, params ListUpcomingTasksParams)
+	// Delete a task.
+	// (DELETE /tasks/{id})
+	DeleteTask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params DeleteTaskParams)
+	// Retrieve a task by ID.
+	// (GET /tasks/{id})
+	GetTask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Create or update a task.
+	// (PUT /tasks/{id})
+	PutTask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Complete a task.
+	// (POST /tasks/{id}/complete)
+	CompleteTask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params CompleteTaskParams)
+	// Move a task.
+	// (PUT /tasks/{id}/parent)
+	MoveTask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Report a task's progress.
+	// (GET /tasks/{id}/progress)
+	GetTaskProgress(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Reopen a task.
+	// (POST /tasks/{id}/reopen)
+	ReopenTask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params ReopenTaskParams)
+	// Change the status of a task.
+	// (POST /tasks/{id}/status)
+	TransitionTask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params TransitionTaskParams)
+	// List a task's subtasks.
+	// (GET /tasks/{id}/subtasks)
+	ListSubtasks(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Create a subtask.
+	// (POST /tasks/{id}/subtasks)
+	CreateSubtask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -40,42 +94,25 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// GetProject operation middleware
-func (siw *ServerInterfaceWrapper) GetProject(w http.ResponseWriter, r *http.Request) {
+// ListProjects operation middleware
+func (siw *ServerInterfaceWrapper) ListProjects(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetProjectParams
-
-	headers := r.Header
-
-	// ------------- Required header parameter "projectName" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("projectName")]; found {
-		var ProjectName string
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "projectName", Count: n})
-			return
-		}
-
-		err = runtime.BindStyledParameterWithLocation("simple", false, "projectName", runtime.ParamLocationHeader, valueList[0], &ProjectName)
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectName", Err: err})
-			return
-		}
+	var params ListProjectsParams
 
-		params.ProjectName = ProjectName
+	// ------------- Optional query parameter "id" -------------
 
-	} else {
-		err := fmt.Errorf("Header parameter projectName is required, but not found")
-		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "projectName", Err: err})
+	err = runtime.BindQueryParameter("form", true, false, "id", r.URL.Query(), &params.Id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetProject(w, r, params)
+		siw.Handler.ListProjects(w, r, params)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -85,12 +122,23 @@ func (siw *ServerInterfaceWrapper) GetProject(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PostProject operation middleware
-func (siw *ServerInterfaceWrapper) PostProject(w http.ResponseWriter, r *http.Request) {
+// DeleteProject operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProject(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostProject(w, r)
+		siw.Handler.DeleteProject(w, r, name)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -100,265 +148,2138 @@ func (siw *ServerInterfaceWrapper) PostProject(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// GetProject operation middleware
+func (siw *ServerInterfaceWrapper) GetProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "name" -------------
+	var name string
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProject(w, r, name)
+	})
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type RequiredParamError struct {
-	ParamName string
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+// PutProject operation middleware
+func (siw *ServerInterfaceWrapper) PutProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	var err error
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	// ------------- Path parameter "name" -------------
+	var name string
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutProject(w, r, name)
+	})
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+// ListTasks operation middleware
+func (siw *ServerInterfaceWrapper) ListTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	var err error
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTasksParams
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	// ------------- Optional query parameter "limit" -------------
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
-}
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
-}
+	// ------------- Optional query parameter "cursor" -------------
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
 
-	if r == nil {
-		r = chi.NewRouter()
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", r.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
 	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+
+	// ------------- Optional query parameter "dueOn" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dueOn", r.URL.Query(), &params.DueOn)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dueOn", Err: err})
+		return
 	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+
+	// ------------- Optional query parameter "undated" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "undated", r.URL.Query(), &params.Undated)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "undated", Err: err})
+		return
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/project", wrapper.GetProject)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/project", wrapper.PostProject)
-	})
+	// ------------- Optional query parameter "dueFrom" -------------
 
-	return r
-}
+	err = runtime.BindQueryParameter("form", true, false, "dueFrom", r.URL.Query(), &params.DueFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dueFrom", Err: err})
+		return
+	}
 
-type GetProjectRequestObject struct {
-	Params GetProjectParams
-}
+	// ------------- Optional query parameter "dueTo" -------------
 
-type GetProjectResponseObject interface {
-	VisitGetProjectResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindQueryParameter("form", true, false, "dueTo", r.URL.Query(), &params.DueTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dueTo", Err: err})
+		return
+	}
 
-type GetProject200RequestBodyResponse struct {
-	Body          io.Reader
-	ContentLength int64
-}
+	// ------------- Optional query parameter "projectId" -------------
 
-func (response GetProject200RequestBodyResponse) VisitGetProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "requestBody")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	err = runtime.BindQueryParameter("form", true, false, "projectId", r.URL.Query(), &params.ProjectId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectId", Err: err})
+		return
 	}
-	w.WriteHeader(200)
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
+	// ------------- Optional query parameter "tz" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tz", r.URL.Query(), &params.Tz)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tz", Err: err})
+		return
 	}
-	_, err := io.Copy(w, response.Body)
-	return err
-}
 
-type GetProject404Response struct {
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTasks(w, r, params)
+	})
 
-func (response GetProject404Response) VisitGetProjectResponse(w http.ResponseWriter) error {
-	w.WriteHeader(404)
-	return nil
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetProjectdefaultJSONResponse struct {
-	Body       ProblemDetails
-	StatusCode int
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (response GetProjectdefaultJSONResponse) VisitGetProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+// CreateTask operation middleware
+func (siw *ServerInterfaceWrapper) CreateTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response.Body)
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateTask(w, r)
+	})
 
-type PostProjectRequestObject struct {
-	Body *PostProjectJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type PostProjectResponseObject interface {
-	VisitPostProjectResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type PostProject201TextResponse string
+// CountTasks operation middleware
+func (siw *ServerInterfaceWrapper) CountTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response PostProject201TextResponse) VisitPostProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "text/plain")
-	w.WriteHeader(201)
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CountTasks(w, r)
+	})
 
-	_, err := w.Write([]byte(response))
-	return err
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type PostProjectdefaultJSONResponse struct {
-	Body       ProblemDetails
-	StatusCode int
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (response PostProjectdefaultJSONResponse) VisitPostProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+// ListOverdueTasks operation middleware
+func (siw *ServerInterfaceWrapper) ListOverdueTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response.Body)
-}
+	var err error
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Retrieve a project by name.
-	// (GET /project)
-	GetProject(ctx context.Context, request GetProjectRequestObject) (GetProjectResponseObject, error)
-	// Create or update a project's metadata.
-	// (POST /project)
-	PostProject(ctx context.Context, request PostProjectRequestObject) (PostProjectResponseObject, error)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListOverdueTasksParams
 
-type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error)
+	// ------------- Optional query parameter "tz" -------------
 
-type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+	err = runtime.BindQueryParameter("form", true, false, "tz", r.URL.Query(), &params.Tz)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tz", Err: err})
+		return
+	}
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListOverdueTasks(w, r, params)
+	})
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
+// SearchTasks operation middleware
+func (siw *ServerInterfaceWrapper) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// GetProject operation middleware
-func (sh *strictHandler) GetProject(w http.ResponseWriter, r *http.Request, params GetProjectParams) {
-	var request GetProjectRequestObject
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchTasksParams
+
+	// ------------- Optional query parameter "completed" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "completed", r.URL.Query(), &params.Completed)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "completed", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "minPriority" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minPriority", r.URL.Query(), &params.MinPriority)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minPriority", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "maxPriority" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxPriority", r.URL.Query(), &params.MaxPriority)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "maxPriority", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "dueFrom" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dueFrom", r.URL.Query(), &params.DueFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dueFrom", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "dueTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dueTo", r.URL.Query(), &params.DueTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dueTo", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdFrom" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdFrom", r.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdFrom", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdTo", r.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdTo", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "projectId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "projectId", r.URL.Query(), &params.ProjectId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tz" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tz", r.URL.Query(), &params.Tz)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tz", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchTasks(w, r, params)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListUpcomingTasks operation middleware
+func (siw *ServerInterfaceWrapper) ListUpcomingTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUpcomingTasksParams
+
+	// ------------- Optional query parameter "days" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "days", r.URL.Query(), &params.Days)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "days", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "includeCompleted" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeCompleted", r.URL.Query(), &params.IncludeCompleted)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeCompleted", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tz" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tz", r.URL.Query(), &params.Tz)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tz", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUpcomingTasks(w, r, params)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteTask operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteTaskParams
+
+	// ------------- Optional query parameter "subtasks" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "subtasks", r.URL.Query(), &params.Subtasks)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subtasks", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTask(w, r, id, params)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTask operation middleware
+func (siw *ServerInterfaceWrapper) GetTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTask(w, r, id)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PutTask operation middleware
+func (siw *ServerInterfaceWrapper) PutTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutTask(w, r, id)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CompleteTask operation middleware
+func (siw *ServerInterfaceWrapper) CompleteTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CompleteTaskParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Actor")]; found {
+		var XActor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Actor", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Actor", runtime.ParamLocationHeader, valueList[0], &XActor)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Actor", Err: err})
+			return
+		}
+
+		params.XActor = &XActor
+
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CompleteTask(w, r, id, params)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// MoveTask operation middleware
+func (siw *ServerInterfaceWrapper) MoveTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MoveTask(w, r, id)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTaskProgress operation middleware
+func (siw *ServerInterfaceWrapper) GetTaskProgress(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTaskProgress(w, r, id)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReopenTask operation middleware
+func (siw *ServerInterfaceWrapper) ReopenTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReopenTaskParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Actor")]; found {
+		var XActor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Actor", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Actor", runtime.ParamLocationHeader, valueList[0], &XActor)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Actor", Err: err})
+			return
+		}
+
+		params.XActor = &XActor
+
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReopenTask(w, r, id, params)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// TransitionTask operation middleware
+func (siw *ServerInterfaceWrapper) TransitionTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TransitionTaskParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Actor")]; found {
+		var XActor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Actor", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Actor", runtime.ParamLocationHeader, valueList[0], &XActor)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Actor", Err: err})
+			return
+		}
+
+		params.XActor = &XActor
+
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TransitionTask(w, r, id, params)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListSubtasks operation middleware
+func (siw *ServerInterfaceWrapper) ListSubtasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSubtasks(w, r, id)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateSubtask operation middleware
+func (siw *ServerInterfaceWrapper) CreateSubtask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSubtask(w, r, id)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshallingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshallingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshalling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshallingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/projects", wrapper.ListProjects)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/projects/{name}", wrapper.DeleteProject)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/projects/{name}", wrapper.GetProject)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/projects/{name}", wrapper.PutProject)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tasks", wrapper.ListTasks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tasks", wrapper.CreateTask)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tasks/count", wrapper.CountTasks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tasks/overdue", wrapper.ListOverdueTasks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tasks/search", wrapper.SearchTasks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tasks/upcoming", wrapper.ListUpcomingTasks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/tasks/{id}", wrapper.DeleteTask)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tasks/{id}", wrapper.GetTask)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/tasks/{id}", wrapper.PutTask)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tasks/{id}/complete", wrapper.CompleteTask)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/tasks/{id}/parent", wrapper.MoveTask)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tasks/{id}/progress", wrapper.GetTaskProgress)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tasks/{id}/reopen", wrapper.ReopenTask)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tasks/{id}/status", wrapper.TransitionTask)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tasks/{id}/subtasks", wrapper.ListSubtasks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tasks/{id}/subtasks", wrapper.CreateSubtask)
+	})
+
+	return r
+}
+
+type ListProjectsRequestObject struct {
+	Params ListProjectsParams
+}
+
+type ListProjectsResponseObject interface {
+	VisitListProjectsResponse(w http.ResponseWriter) error
+}
+
+type ListProjects200JSONResponse []Project
+
+func (response ListProjects200JSONResponse) VisitListProjectsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProjectsdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response ListProjectsdefaultJSONResponse) VisitListProjectsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteProjectRequestObject struct {
+	Name string `json:"name"`
+}
+
+type DeleteProjectResponseObject interface {
+	VisitDeleteProjectResponse(w http.ResponseWriter) error
+}
+
+type DeleteProject204Response struct {
+}
+
+func (response DeleteProject204Response) VisitDeleteProjectResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteProjectdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response DeleteProjectdefaultJSONResponse) VisitDeleteProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetProjectRequestObject struct {
+	Name string `json:"name"`
+}
+
+type GetProjectResponseObject interface {
+	VisitGetProjectResponse(w http.ResponseWriter) error
+}
+
+type GetProject200JSONResponse Project
+
+func (response GetProject200JSONResponse) VisitGetProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProjectdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetProjectdefaultJSONResponse) VisitGetProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PutProjectRequestObject struct {
+	Name string `json:"name"`
+	Body *PutProjectJSONRequestBody
+}
+
+type PutProjectResponseObject interface {
+	VisitPutProjectResponse(w http.ResponseWriter) error
+}
+
+type PutProject200JSONResponse Project
+
+func (response PutProject200JSONResponse) VisitPutProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutProject201JSONResponse Project
+
+func (response PutProject201JSONResponse) VisitPutProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutProjectdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response PutProjectdefaultJSONResponse) VisitPutProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListTasksRequestObject struct {
+	Params ListTasksParams
+}
+
+type ListTasksResponseObject interface {
+	VisitListTasksResponse(w http.ResponseWriter) error
+}
+
+type ListTasks200ResponseHeaders struct {
+	Link        string
+	XNextCursor string
+}
+
+type ListTasks200JSONResponse struct {
+	Body    []Task
+	Headers ListTasks200ResponseHeaders
+}
+
+func (response ListTasks200JSONResponse) VisitListTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListTasksdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response ListTasksdefaultJSONResponse) VisitListTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateTaskRequestObject struct {
+	Body *CreateTaskJSONRequestBody
+}
+
+type CreateTaskResponseObject interface {
+	VisitCreateTaskResponse(w http.ResponseWriter) error
+}
+
+type CreateTask201JSONResponse Task
+
+func (response CreateTask201JSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTaskdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response CreateTaskdefaultJSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CountTasksRequestObject struct {
+}
+
+type CountTasksResponseObject interface {
+	VisitCountTasksResponse(w http.ResponseWriter) error
+}
+
+type CountTasks200JSONResponse TaskCount
+
+func (response CountTasks200JSONResponse) VisitCountTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CountTasksdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response CountTasksdefaultJSONResponse) VisitCountTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListOverdueTasksRequestObject struct {
+	Params ListOverdueTasksParams
+}
+
+type ListOverdueTasksResponseObject interface {
+	VisitListOverdueTasksResponse(w http.ResponseWriter) error
+}
+
+type ListOverdueTasks200JSONResponse []Task
+
+func (response ListOverdueTasks200JSONResponse) VisitListOverdueTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOverdueTasksdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response ListOverdueTasksdefaultJSONResponse) VisitListOverdueTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SearchTasksRequestObject struct {
+	Params SearchTasksParams
+}
+
+type SearchTasksResponseObject interface {
+	VisitSearchTasksResponse(w http.ResponseWriter) error
+}
+
+type SearchTasks200JSONResponse []Task
+
+func (response SearchTasks200JSONResponse) VisitSearchTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchTasksdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response SearchTasksdefaultJSONResponse) VisitSearchTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListUpcomingTasksRequestObject struct {
+	Params ListUpcomingTasksParams
+}
+
+type ListUpcomingTasksResponseObject interface {
+	VisitListUpcomingTasksResponse(w http.ResponseWriter) error
+}
+
+type ListUpcomingTasks200JSONResponse []Task
+
+func (response ListUpcomingTasks200JSONResponse) VisitListUpcomingTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUpcomingTasksdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response ListUpcomingTasksdefaultJSONResponse) VisitListUpcomingTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteTaskRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params DeleteTaskParams
+}
+
+type DeleteTaskResponseObject interface {
+	VisitDeleteTaskResponse(w http.ResponseWriter) error
+}
+
+type DeleteTask204Response struct {
+}
+
+func (response DeleteTask204Response) VisitDeleteTaskResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteTaskdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response DeleteTaskdefaultJSONResponse) VisitDeleteTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTaskRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetTaskResponseObject interface {
+	VisitGetTaskResponse(w http.ResponseWriter) error
+}
+
+type GetTask200JSONResponse Task
+
+func (response GetTask200JSONResponse) VisitGetTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTaskdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetTaskdefaultJSONResponse) VisitGetTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PutTaskRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *PutTaskJSONRequestBody
+}
+
+type PutTaskResponseObject interface {
+	VisitPutTaskResponse(w http.ResponseWriter) error
+}
+
+type PutTask200JSONResponse Task
+
+func (response PutTask200JSONResponse) VisitPutTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutTask201JSONResponse Task
+
+func (response PutTask201JSONResponse) VisitPutTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutTaskdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response PutTaskdefaultJSONResponse) VisitPutTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CompleteTaskRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params CompleteTaskParams
+}
+
+type CompleteTaskResponseObject interface {
+	VisitCompleteTaskResponse(w http.ResponseWriter) error
+}
+
+type CompleteTask200ResponseHeaders struct {
+	Link            string
+	XNextOccurrence openapi_types.UUID
+}
+
+type CompleteTask200JSONResponse struct {
+	Body    Task
+	Headers CompleteTask200ResponseHeaders
+}
+
+func (response CompleteTask200JSONResponse) VisitCompleteTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.Header().Set("X-Next-Occurrence", fmt.Sprint(response.Headers.XNextOccurrence))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CompleteTaskdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response CompleteTaskdefaultJSONResponse) VisitCompleteTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type MoveTaskRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *MoveTaskJSONRequestBody
+}
+
+type MoveTaskResponseObject interface {
+	VisitMoveTaskResponse(w http.ResponseWriter) error
+}
+
+type MoveTask200JSONResponse Task
+
+func (response MoveTask200JSONResponse) VisitMoveTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MoveTaskdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response MoveTaskdefaultJSONResponse) VisitMoveTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTaskProgressRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetTaskProgressResponseObject interface {
+	VisitGetTaskProgressResponse(w http.ResponseWriter) error
+}
+
+type GetTaskProgress200JSONResponse TaskProgress
+
+func (response GetTaskProgress200JSONResponse) VisitGetTaskProgressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTaskProgressdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetTaskProgressdefaultJSONResponse) VisitGetTaskProgressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReopenTaskRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params ReopenTaskParams
+}
+
+type ReopenTaskResponseObject interface {
+	VisitReopenTaskResponse(w http.ResponseWriter) error
+}
+
+type ReopenTask200JSONResponse Task
+
+func (response ReopenTask200JSONResponse) VisitReopenTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReopenTaskdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response ReopenTaskdefaultJSONResponse) VisitReopenTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type TransitionTaskRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params TransitionTaskParams
+	Body   *TransitionTaskJSONRequestBody
+}
+
+type TransitionTaskResponseObject interface {
+	VisitTransitionTaskResponse(w http.ResponseWriter) error
+}
+
+type TransitionTask200ResponseHeaders struct {
+	Link            string
+	XNextOccurrence openapi_types.UUID
+}
+
+type TransitionTask200JSONResponse struct {
+	Body    Task
+	Headers TransitionTask200ResponseHeaders
+}
+
+func (response TransitionTask200JSONResponse) VisitTransitionTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.Header().Set("X-Next-Occurrence", fmt.Sprint(response.Headers.XNextOccurrence))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type TransitionTaskdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response TransitionTaskdefaultJSONResponse) VisitTransitionTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListSubtasksRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type ListSubtasksResponseObject interface {
+	VisitListSubtasksResponse(w http.ResponseWriter) error
+}
+
+type ListSubtasks200JSONResponse []Task
+
+func (response ListSubtasks200JSONResponse) VisitListSubtasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListSubtasksdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response ListSubtasksdefaultJSONResponse) VisitListSubtasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateSubtaskRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *CreateSubtaskJSONRequestBody
+}
+
+type CreateSubtaskResponseObject interface {
+	VisitCreateSubtaskResponse(w http.ResponseWriter) error
+}
+
+type CreateSubtask201JSONResponse Task
+
+func (response CreateSubtask201JSONResponse) VisitCreateSubtaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSubtaskdefaultJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response CreateSubtaskdefaultJSONResponse) VisitCreateSubtaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// List projects.
+	// (GET /projects)
+	ListProjects(ctx context.Context, request ListProjectsRequestObject) (ListProjectsResponseObject, error)
+	// Delete a project.
+	// (DELETE /projects/{name})
+	DeleteProject(ctx context.Context, request DeleteProjectRequestObject) (DeleteProjectResponseObject, error)
+	// Retrieve a project by name.
+	// (GET /projects/{name})
+	GetProject(ctx context.Context, request GetProjectRequestObject) (GetProjectResponseObject, error)
+	// Create or update a project's metadata.
+	// (PUT /projects/{name})
+	PutProject(ctx context.Context, request PutProjectRequestObject) (PutProjectResponseObject, error)
+	// List tasks.
+	// (GET /tasks)
+	ListTasks(ctx context.Context, request ListTasksRequestObject) (ListTasksResponseObject, error)
+	// Create a task.
+	// (POST /tasks)
+	CreateTask(ctx context.Context, request CreateTaskRequestObject) (CreateTaskResponseObject, error)
+	// Count tasks.
+	// (GET /tasks/count)
+	CountTasks(ctx context.Context, request CountTasksRequestObject) (CountTasksResponseObject, error)
+	// List overdue tasks.
+	// (GET /tasks/overdue)
+	ListOverdueTasks(ctx context.Context, request ListOverdueTasksRequestObject) (ListOverdueTasksResponseObject, error)
+	// Search tasks.
+	// (GET /tasks/search)
+	SearchTasks(ctx context.Context, request SearchTasksRequestObject) (SearchTasksResponseObject, error)
+	// List upcoming tasks.
+	// (GET /tasks/upcoming)
+	ListUpcomingTasks(ctx context.Context, request ListUpcomingTasksRequestObject) (ListUpcomingTasksResponseObject, error)
+	// Delete a task.
+	// (DELETE /tasks/{id})
+	DeleteTask(ctx context.Context, request DeleteTaskRequestObject) (DeleteTaskResponseObject, error)
+	// Retrieve a task by ID.
+	// (GET /tasks/{id})
+	GetTask(ctx context.Context, request GetTaskRequestObject) (GetTaskResponseObject, error)
+	// Create or update a task.
+	// (PUT /tasks/{id})
+	PutTask(ctx context.Context, request PutTaskRequestObject) (PutTaskResponseObject, error)
+	// Complete a task.
+	// (POST /tasks/{id}/complete)
+	CompleteTask(ctx context.Context, request CompleteTaskRequestObject) (CompleteTaskResponseObject, error)
+	// Move a task.
+	// (PUT /tasks/{id}/parent)
+	MoveTask(ctx context.Context, request MoveTaskRequestObject) (MoveTaskResponseObject, error)
+	// Report a task's progress.
+	// (GET /tasks/{id}/progress)
+	GetTaskProgress(ctx context.Context, request GetTaskProgressRequestObject) (GetTaskProgressResponseObject, error)
+	// Reopen a task.
+	// (POST /tasks/{id}/reopen)
+	ReopenTask(ctx context.Context, request ReopenTaskRequestObject) (ReopenTaskResponseObject, error)
+	// Change the status of a task.
+	// (POST /tasks/{id}/status)
+	TransitionTask(ctx context.Context, request TransitionTaskRequestObject) (TransitionTaskResponseObject, error)
+	// List a task's subtasks.
+	// (GET /tasks/{id}/subtasks)
+	ListSubtasks(ctx context.Context, request ListSubtasksRequestObject) (ListSubtasksResponseObject, error)
+	// Create a subtask.
+	// (POST /tasks/{id}/subtasks)
+	CreateSubtask(ctx context.Context, request CreateSubtaskRequestObject) (CreateSubtaskResponseObject, error)
+}
+
+type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error)
+
+type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ListProjects operation middleware
+func (sh *strictHandler) ListProjects(w http.ResponseWriter, r *http.Request, params ListProjectsParams) {
+	var request ListProjectsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListProjects(ctx, request.(ListProjectsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListProjects")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListProjectsResponseObject); ok {
+		if err := validResponse.VisitListProjectsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// DeleteProject operation middleware
+func (sh *strictHandler) DeleteProject(w http.ResponseWriter, r *http.Request, name string) {
+	var request DeleteProjectRequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteProject(ctx, request.(DeleteProjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteProject")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteProjectResponseObject); ok {
+		if err := validResponse.VisitDeleteProjectResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetProject operation middleware
+func (sh *strictHandler) GetProject(w http.ResponseWriter, r *http.Request, name string) {
+	var request GetProjectRequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProject(ctx, request.(GetProjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProject")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProjectResponseObject); ok {
+		if err := validResponse.VisitGetProjectResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// PutProject operation middleware
+func (sh *strictHandler) PutProject(w http.ResponseWriter, r *http.Request, name string) {
+	var request PutProjectRequestObject
+
+	request.Name = name
+
+	var body PutProjectJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutProject(ctx, request.(PutProjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutProject")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutProjectResponseObject); ok {
+		if err := validResponse.VisitPutProjectResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// ListTasks operation middleware
+func (sh *strictHandler) ListTasks(w http.ResponseWriter, r *http.Request, params ListTasksParams) {
+	var request ListTasksRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListTasks(ctx, request.(ListTasksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListTasks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListTasksResponseObject); ok {
+		if err := validResponse.VisitListTasksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CreateTask operation middleware
+func (sh *strictHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
+	var request CreateTaskRequestObject
+
+	var body CreateTaskJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateTask(ctx, request.(CreateTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateTaskResponseObject); ok {
+		if err := validResponse.VisitCreateTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CountTasks operation middleware
+func (sh *strictHandler) CountTasks(w http.ResponseWriter, r *http.Request) {
+	var request CountTasksRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CountTasks(ctx, request.(CountTasksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CountTasks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CountTasksResponseObject); ok {
+		if err := validResponse.VisitCountTasksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// ListOverdueTasks operation middleware
+func (sh *strictHandler) ListOverdueTasks(w http.ResponseWriter, r *http.Request, params ListOverdueTasksParams) {
+	var request ListOverdueTasksRequestObject
 
 	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetProject(ctx, request.(GetProjectRequestObject))
+		return sh.ssi.ListOverdueTasks(ctx, request.(ListOverdueTasksRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetProject")
+		handler = middleware(handler, "ListOverdueTasks")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetProjectResponseObject); ok {
-		if err := validResponse.VisitGetProjectResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListOverdueTasksResponseObject); ok {
+		if err := validResponse.VisitListOverdueTasksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// SearchTasks operation middleware
+func (sh *strictHandler) SearchTasks(w http.ResponseWriter, r *http.Request, params SearchTasksParams) {
+	var request SearchTasksRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchTasks(ctx, request.(SearchTasksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchTasks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SearchTasksResponseObject); ok {
+		if err := validResponse.VisitSearchTasksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// ListUpcomingTasks operation middleware
+func (sh *strictHandler) ListUpcomingTasks(w http.ResponseWriter, r *http.Request, params ListUpcomingTasksParams) {
+	var request ListUpcomingTasksRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListUpcomingTasks(ctx, request.(ListUpcomingTasksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListUpcomingTasks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListUpcomingTasksResponseObject); ok {
+		if err := validResponse.VisitListUpcomingTasksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// DeleteTask operation middleware
+func (sh *strictHandler) DeleteTask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params DeleteTaskParams) {
+	var request DeleteTaskRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteTask(ctx, request.(DeleteTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteTaskResponseObject); ok {
+		if err := validResponse.VisitDeleteTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetTask operation middleware
+func (sh *strictHandler) GetTask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request GetTaskRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTask(ctx, request.(GetTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTaskResponseObject); ok {
+		if err := validResponse.VisitGetTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// PutTask operation middleware
+func (sh *strictHandler) PutTask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request PutTaskRequestObject
+
+	request.Id = id
+
+	var body PutTaskJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutTask(ctx, request.(PutTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutTaskResponseObject); ok {
+		if err := validResponse.VisitPutTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CompleteTask operation middleware
+func (sh *strictHandler) CompleteTask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params CompleteTaskParams) {
+	var request CompleteTaskRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CompleteTask(ctx, request.(CompleteTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CompleteTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CompleteTaskResponseObject); ok {
+		if err := validResponse.VisitCompleteTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// MoveTask operation middleware
+func (sh *strictHandler) MoveTask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request MoveTaskRequestObject
+
+	request.Id = id
+
+	var body MoveTaskJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.MoveTask(ctx, request.(MoveTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "MoveTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(MoveTaskResponseObject); ok {
+		if err := validResponse.VisitMoveTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetTaskProgress operation middleware
+func (sh *strictHandler) GetTaskProgress(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request GetTaskProgressRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTaskProgress(ctx, request.(GetTaskProgressRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTaskProgress")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTaskProgressResponseObject); ok {
+		if err := validResponse.VisitGetTaskProgressResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// ReopenTask operation middleware
+func (sh *strictHandler) ReopenTask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params ReopenTaskParams) {
+	var request ReopenTaskRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReopenTask(ctx, request.(ReopenTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReopenTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReopenTaskResponseObject); ok {
+		if err := validResponse.VisitReopenTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// TransitionTask operation middleware
+func (sh *strictHandler) TransitionTask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params TransitionTaskParams) {
+	var request TransitionTaskRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	var body TransitionTaskJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.TransitionTask(ctx, request.(TransitionTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "TransitionTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(TransitionTaskResponseObject); ok {
+		if err := validResponse.VisitTransitionTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// ListSubtasks operation middleware
+func (sh *strictHandler) ListSubtasks(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request ListSubtasksRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListSubtasks(ctx, request.(ListSubtasksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListSubtasks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListSubtasksResponseObject); ok {
+		if err := validResponse.VisitListSubtasksResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -366,11 +2287,13 @@ func (sh *strictHandler) GetProject(w http.ResponseWriter, r *http.Request, para
 	}
 }
 
-// PostProject operation middleware
-func (sh *strictHandler) PostProject(w http.ResponseWriter, r *http.Request) {
-	var request PostProjectRequestObject
+// CreateSubtask operation middleware
+func (sh *strictHandler) CreateSubtask(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request CreateSubtaskRequestObject
+
+	request.Id = id
 
-	var body PostProjectJSONRequestBody
+	var body CreateSubtaskJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -378,18 +2301,18 @@ func (sh *strictHandler) PostProject(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostProject(ctx, request.(PostProjectRequestObject))
+		return sh.ssi.CreateSubtask(ctx, request.(CreateSubtaskRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostProject")
+		handler = middleware(handler, "CreateSubtask")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostProjectResponseObject); ok {
-		if err := validResponse.VisitPostProjectResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateSubtaskResponseObject); ok {
+		if err := validResponse.VisitCreateSubtaskResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -400,22 +2323,78 @@ func (sh *strictHandler) PostProject(w http.ResponseWriter, r *http.Request) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8xVQW8bNxP9K4P5PqAtulnJsZG2e0tTxA2QBoarnAIjGJEjLe1dDkPOOhEM//eCXMmS",
-	"JRVx0Rx6ErUkZ96892Z4h0b6IJ69JmzuMJmWeyrLiyjzjvvfWMl15QtZ69SJp+4iSuCojhM2GgeuMOx8",
-	"uUNbLo2rZKIL+Ro2+BLaoSf/LDJZmncM/CV05ClvQwps3MIZUAFtXQIxZoiRvWGQBWjLEEZMNVa4kNiT",
-	"YoPKXxQr1FVgbDBpdH6J9xU6n5S84WMo3l++gcgLHoNrSwrOsle3cJxKpgcw/w5EUtIhHUKYtQy/z2YX",
-	"MB4AI5bh+w+Xr1/99Pz05KqCP9kUUl78AEv2HEnZwnxVAEh0S+chcbzlCAuJT6Brjcx55SXHDE2ddkfJ",
-	"Sa1ErfaVSkPfU1zthYYc90lMjB++JkVm4PSXn19cHRXlHyZ9yIoyv2ajGcZFlLJs7g4su4NrH+b6Fux+",
-	"PVKjp57//nLereGPISnMGQbvPg0MZKKkBNR1ubp8LtVPqeS+OHwhRwV0fegYyFvoKC65W0EQ57XjnCmE",
-	"zpmx4wrHlnvxSbPDEswH11nnl9lRfpnAeTiXAmh0C87kXOAZUG5SK4+ifY5Olf14Byu85ZhGSNP6pJ5m",
-	"giSwp+CwwdN6Wk+xwkDaFv4nYavMkvWwrrciNzAEoA1RuR0yp6XQyDpEDwQbtkeiargsGwkIzqZn4B7c",
-	"Ww4Z8l6KHAsZvM3Q8/aczE0mIanEYrRslFLkG4sNnrNubJQLiNSzckzYfDjW5gWiPM6rkgFHx7clvMtn",
-	"WybLETcuwvXZd/lfhZE/DS6y3czbcVBnlvatcpUPpyA+jcZ+Pp3mHyNe2RdecyxO+qvY1Xbm59X/Iy+w",
-	"wf9Nto/CZP0iTPaeg2LAx8W+zhRmlc+mZ4fyvRMdScZycUFDp3u4dsw0uU5jH34rcByjxNJI60GGDV6u",
-	"JTi0VJ1BBklHbPgqcukUiTAEW5ZZ2Z6VLCllpR/C1TDLg/mjFU7gRT9CopzOr0Ap3SSglMS4Mts/O213",
-	"PVLDTIBuxVnwP54UK/apgus8PkoU05Jfcm5R626dHajrVodevZC0Y9Y95b8V95uRdH9gvZO9NHlQT0JH",
-	"bi/B1yb5gZ4vjeGg/B9y02iMrS+2NvguPdijHqONT/c4Md5fvsUGJ3ksVjjEDhvE+6v7vwIAAP//0pQP",
-	"GJoJAAA=",
+	"H4sIAAAAAAAC/+xce3MbN5L/Kl1zW+Xd2hFFx856o1T+0Ep2ojo/dAp13pxXlwJnmiSiGWACYEQxPn73",
+	"q25gHiRnRMqRHGnLf0nkYIBGox+/foAfo0TnhVaonI0OPkaFMCJHh4Y/HSZOG/onRZsYWTipVXQQvZ9p",
+	"kBZycSnVFNwMIZkJNcUYDCbapJjCXLoZSAdS8XMn7OUTC84IZSXNYgdRHEmabIYiRRPFkRI5RgfRP/f8",
+	"qnFkkxnmgpZ3i4IeWWekmkbLZRyNZI7/oxVuEjeaIZwcvj0EJ3OE37TCGGyZzEBYOMzRyETsv8X5zz9p",
+	"cxmDmwkHqVhYmMorVKANyLzIJKYwXjDpBn8t0ToQBkGqARzyZmj/aYmgFQh6H+YzVCCd/zYVDmEisszC",
+	"GN0c0XMhl6mS05mzYJ0wjpgnVAqo0oqPNBOzTNqG/ppVv5ZoFg2n3G8rTEpxIsrMRQfR+egoijeYtqwG",
+	"89GeGqmNdIsOBtL2ivCY1kZV5tHBh0gRv+Mo0/MojnJMZZlHcTST01l0sbFcHJ0aPc4wP0YnZMZrijTl",
+	"wxfZqdEFGifRRgfOlBiv0XCo4OzVEbz4+/AFFH4eMFho4wZwWokooDHaWMikdcw9PZkEXjZiTOyU6kpk",
+	"Mt3jb1nyitb6tH8icZMThzArc6H2DIpUjDMEvC4yoQQ9BltgIicyAaf9eekkKY1BlRAhTE+gnBacaJML",
+	"OhuH1y7q4JZU1gmVYBcV52cnYHCCfnIWWZmicnIi0fJKNTG/jwjrhCttt079MBqdgh8AiU4R/vzh7NXR",
+	"i6+ePb2I4UdMmCl/+wtMUaERrlEgbeRUKrBortDARJsd2BUok8rhFA2R5qTLOpljZ9q4eP2kbJnnwizW",
+	"pgaadydO+C+2HQVx4Nk3f//bReeh3HLRetVIj3/BxAUd4n8PPq6JbGKQeEz/1tOS1dkjq9G1oZWNrO8r",
+	"LAPtb7tkdHW9spRp1zBvnvrWoKcDeFNaB2OEUslfSwSRGG0tiCyDwo+zg04OkTWWhjb+IeLVebG45sdF",
+	"PxNPVFHezMl1LxfMdqAI5sJCGD2AY29tLam/0nOWa4Xzmny27NLCJRYOSuUdZMrD8FpaNv5aoV0RjM90",
+	"gh0O87ilKTyR02Gv5BIN8qlF8bbD75LhH3uMyvsZGgTh3al1QqV2AEc6LzIk60H8S8giZhmmPMiyD55I",
+	"Je0M07Zn0gUqdpI/F0ZPDVobxdE408klEpFzIYnfJCbV9PR/NXmn9/JUH/GxbQpNYyj/ZHASHUT/sd/g",
+	"qP3gZvfDztflNrzcJarkeztEtKZ6Z3W/W/tAZG0TrbTEY+F6ABnhmgoHBugUk2dOS0ZxG0rQswChvu4F",
+	"aFskwtsWgvfSzXTpSPfWB4JQizCRgrAdlrJrQfyPDqKnLw6eDaM4KoRzaGjt//3zh+HTiw/DvW8u/u+r",
+	"D8O9Zxd/Ofgw3Pvaf/Wnnn3silx5R54Q1ofAghjOR0cecWY4caBLt0rnOs69c2POAuEt+VuRo4WUrKAD",
+	"haSrurHsg645C2FQuZMeW8Qn4hFwOBsBthzzBz3ZwQjFUdECtzcpaA2C+R3vJXqoqu1iReEYM62mZP93",
+	"IslgBXe65z87O3/9spndYIHCWRgvBnD07vztiPhAT1WZj9GQYDQAyrIYkJwnWRliCUJYqpv9tzNecdSK",
+	"2jZpf3mFZhHiP6LKTx6DzlK0DibSWBZO6TDfuuaoXqnBYJEwRixudP71cTd2L95mZY90qbrQQPX1OgJd",
+	"W96P65u8D2q07XgP2ODTZ6RRDR7AO5Utms/BF87EFYKPbnmnkLYQSTM4xKUkPhYdWxGngRwmaDdDM5cW",
+	"wwoGRRjfNoyBozEI1SAXfniJWFiOefVc7Y5jtgOuhgfb0FaABZ8Van1xhv9+zvCLl7t/L3cWXFo998To",
+	"fCVlFoMgZebUz9dfP/86OMVM5tJ5jr86e/lfMZy8Hb08++/D1zH846fjw59iOH87OnnNQsG+Mg66zxLA",
+	"73z3/uXL/3z907c8/rs37+LRDwM48/acOF9RsCo5PW/eu089mSptyOQSq0LexBtb+mImrdM+tYHsfL0A",
+	"OYvZZAB+CbSVS3Yzo8vpDPZplN3/KNPlfuUd4pVvgxshLra/NkjO4hNdOLkU8i1Vlu9Gl85K2OdST1mb",
+	"Nn3qLlqmIddXLQtXqhTNAF4ju9CSg91cXCL5SQFOF3sZXmHGgz8x6GWSq1j0NkDgBz2HnGxviMdTaUgh",
+	"g2nwIXADDbpTZdqJ7IaZ16es+TITNmYDx+iG3GYTgFcOdCsuagJsT0fngTZCssEb4XYPWseL7uJELlJc",
+	"qUtUnqEFh9QTB5cqIJeNmck23UKb9SemAngZfj+mjW/yasmp4YnuzHxKtm+ksJkwU8wWUGipXIbWgiiK",
+	"TCY+Ve2LHJhrZZ0RDi2MS5lVgQKZd6nge7bwIc0ajfT3GvZYG8jrtWebG+kcKv9OFEdXaKwnaTh4OhgS",
+	"P8hiiEJGB9GzwXAQQMKMj3e/SpDRhym6Lj/hSqNsMG5hOGiTovFJ5SoXRVLDJJH2R6+ldafV3PFKIevD",
+	"+hIB7tI6qxk+qlqxUz457qu6sAVoqi7bbMMFHbgttLJevL8aDr0FUC7YsxZv93+xXiWa+XcyumHfHUHT",
+	"OpiNXulSpR7jhnJRLzEhf/3XTaK20NIu+3SQwGUbVoWQpQ+n10r90sNaUvY/EuuXXlLIuGzKzDF/b9uH",
+	"OYATZ1t5Q44OxqUDpYEwDZoAbkjERf3Whlz5qSsOb5zm8z5iHiCTPWHtzS7jbh18rfUllEUztNY7qNRT",
+	"wPPhc5CrWeNEKPIfY4QJyVlV/h2LhEvF1mnTobrfo+vl7+20ZScleTRKcYbOSLzCjmOIlltMHKEfGrmW",
+	"16+tGhnkxqjxn7Zr8nCtvwJ/EUchxbG67BFH7CuKSDKidP1pJqw3sbRm3CQhwGCRiQR9RiFHJ1LhxADq",
+	"rMBYpwtIhDESOUo4Oe6y3cLRgyZv0ZQv0rpSS59AKutQpAMY0Vc/pxotKO1+BkuokKNfbzus1YnkqcIS",
+	"LRMz0iCutExB/fUpS3duY/iltM7P4uEHuddUXsm0FFm22BT/03JF/Lnd4B86Xdy15Pus1HK5XD/o5R+j",
+	"dedFKoKd/Gr49HOs6KXzAWr6UV1qK5kpjcY/aemCd4ssllvRUwXqLeTCJbOQA6uswURmDo2NacVCTNHW",
+	"cWI7pOww3m0cdnI8gFd+IjL8T9juJzofS4XptxRTv6IIv8rVaHbDpWVFnCLpPTVzTLHjba9qNZWM2oVi",
+	"W8H0wkRnmZ57W5GUxmpDOu8hHdZ+5597b/Ha7R35Ab7TyONlqS6rVQxm3/0rUnjt/hV1w8oRc3wHg8sw",
+	"3LpWkj5EWDqQ1gcqOcXR3c3zdDiMo1xcy5xqnU+H/FGq8LErIOuibJUTtU/AK6lLyyztI81z9+Z2rBvx",
+	"NXOgQdYVeu9ai//8zpVCVxavlYpF31Jpie9UN5LvzrnuukuKMlcTSl3Llyqt6hTrmx1rnaFQt9qtNiAm",
+	"Dk297bitqNuICZp619xoaBvjhGzHpxI30ndNWtVjt46K1tZusqW3Cfnibg/RWI/9unvx84SHZL92jw3j",
+	"0JLJ076W6rIr90AG1OMpBDKd3ipv2NO4ricZhKYkeYOCRyuWqjurl6xYsXr5Wy+2fJhhMIuoR/ja3oCx",
+	"Q+sMM11QOSxbtLrvTo7Z05mAB2RHaOsnYum4H+DZ1EJ3Qp1P73ThxwgARcg5N0Bvv65IB7i3doT0tMIn",
+	"9wbim4J5F0/pwYPkKRFWa1PDUX2FJi3xFhB6PtMWm85uCmELYS1Vpke1qxNcPJA5Ms4Ni4D2LbuhKNu8",
+	"Ga86Sd9EXg+mD2TBqPJTtdD/ttoRvglT3/kle9DqY3ZJD9NOV0e8KWEWhUlmnxKj+fDLBz7+SsIKWKI3",
+	"rDYOLnHhS3WOXJ4PxjaE4kcmY6fopQ2Rqg5LT1vcaibRpqkIVd6WEkX0oFSr7/nHE5HZ/tCiVSy6DQw+",
+	"0nkuwCLtiMiyoeYZg+5Cek3cm/v6bpHpFKMDpq2bMj9jFN9WrFsloRXBjiPrFlxYIfAY7RwxCQcZCusq",
+	"tNpcyuiiOpfqtGmF2lX+wwvL2xCV651pEtf3TFNfCPRQop3PGthUWU/h1hhCvqNXD/1LN3Olpwh7G6La",
+	"jNmBnpG+F2ruO/bbqkMMJnxu3rQbyYAcm5Aq5MfplkgMknpBfCm+35D+erukyYb9rDxKDFXnAZWB46rd",
+	"Kq613HflV115pwYn8hoEvetNwx447aeTircW7mOxC6vA0maWhBFTJqwDlJzjm4vFzqZaG9dtqHsu9dxg",
+	"k79gpDvESB59dKCjskh0TkeyOz5imKUZIhuWnwCKfRqYJIzvcHZA5c5cU1zDplqw69oOuzaZF9o4oUID",
+	"czO+v/fgPOxqJ7jV9OIQ2cFM8/6cDm3c/QkxsbDdqeIXrczwcJfM8IlfaUfE10dQoPeoE83VxAUV7kJ3",
+	"X9TurkOTSsU6tI+a+nZtpaA3fB9F3Sw2xkTnuNYkZ6FU3HVEL9VD6yOEpghSNeLlILj3olY7eoXjCB42",
+	"KS0CU0ibEFW3rPDl42qFvmaNkNG6UQPf02S+uWmFhier03d6nPC8W84jbYqZUO3badUXfvv8DzmlxHXc",
+	"PFte/Hu0mIQ8VtydtvoeXZ12vMec1ePs9fCN1z6k36XNo7m8WTWsdnR4MFrt7+/YoY3txn6Pdi46NND5",
+	"zovQ00HUYSodX86eSMxSS0S375TwjYRX/lHdr6knTe8Ht75mKLg1Gq8TLJy3CTfcTKmuIwWPxt2QnKJT",
+	"6cqvUcChX8OD8+pGjZxM0FjfqN4yENKCUHbOqIJ3/Hz4DRxpNclkVwfZaekeTo79/hXsnto6HmtKv93T",
+	"sZHcX2nHX//9lT9U2TsrT2+EafeLt2+p8bWstYJTdZvb+3B/N6TW0SRYDtJSLuE19xljX84KJoU10vd0",
+	"9fR3vKvf3KnHY5Ws1Xt1dcuW0twa3VE0C+O7QcYWKOt/2uZ349hP0pMWPP+UAm9zPL1lXq1w5drebpXe",
+	"5vS2/TbBhphsX3WbqC8fYNXKn1OvuSiamzAPGRm8EZfYthWt221QXdphwL9+48afajUEZIMG6h9fqvvF",
+	"CE+n/j5PuAHFM/okVriZekO48EZf3Xf5O9xbeiC+mTb8AF0lkdUv761rVN3pIp1lXtJaKK8l90/sxpWn",
+	"sgCpgnWrVKMzQqnvcN3zYdXrPKaIpdDGhWN7wvl03sIDClrWJMlfZXwEMEtftU0nNeDWl/aVnsc+CiL4",
+	"wu2vq6FNvIHCRvwrdvQ2vxIuyvf/Mh+c1YOF8qt2QqN1eEcjMV3HealGvmuXhA6X0MXbuPFN3fPrPzaA",
+	"dRYY8BA1lQ+xz8A2d5cflVo4XQuT34HXDf8TlGFcLdUrSlHTv+XnphISf6rfjbEW7289uuBlW9NXKYLc",
+	"3+RPqmQAvNFX7RSmdzmBXulAZAZFuuC05jb9ukX4FCr1XVHmpro1t3F/t8rdPYZa+R2uB4WivoRRDzqM",
+	"qn7+oNY2Sjf2WcAqo79LRXIdS64ZxM3K4I9NveBL9WqX6pXYrMTcDlL64PGP8FFNPrwV7ja/abRrqzYc",
+	"qkUrBG5dgJTWt4RguilsfvEgbl9auh9KS3eQhIF/0f+ei5fh87PX0UG0LwoZxVFpsuggipYXy/8fAOkt",
+	"M1iVWwAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -1,14 +1,45 @@
 // Package api provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/deepmap/oapi-codegen version v1.11.1-0.20220912230023-4a1477f6a8ba DO NOT EDIT.
+// Code generated by github.com/deepmap/oapi-codegen version v1.12.4 DO NOT EDIT.
 package api
 
 import (
 	"encoding/json"
 	"fmt"
+	"time"
+
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+)
+
+// Defines values for Priority.
+const (
+	High   Priority = "high"
+	Low    Priority = "low"
+	Medium Priority = "medium"
+	None   Priority = "none"
+)
+
+// Defines values for Status.
+const (
+	Blocked    Status = "blocked"
+	Cancelled  Status = "cancelled"
+	Completed  Status = "completed"
+	InProgress Status = "in_progress"
+	Open       Status = "open"
+	Waiting    Status = "waiting"
+)
+
+// Defines values for DeleteTaskParamsSubtasks.
+const (
+	Orphan   DeleteTaskParamsSubtasks = "orphan"
+	Remove   DeleteTaskParamsSubtasks = "remove"
+	Restrict DeleteTaskParamsSubtasks = "restrict"
 )
 
-// ProblemDetails defines model for ProblemDetails.
+// Priority Task priority.
+type Priority string
+
+// ProblemDetails An RFC 7807 problem report. Parameter errors list the offending parameters in invalid-params.
 type ProblemDetails struct {
 	// Detail A human-readable explanation specific to this occurrence of the problem.
 	Detail *string `json:"detail,omitempty"`
@@ -29,21 +60,296 @@ type ProblemDetails struct {
 
 // Project defines model for Project.
 type Project struct {
+	Created time.Time `json:"created"`
+
 	// Description Project description
-	Description *string `json:"description,omitempty"`
+	Description *string            `json:"description,omitempty"`
+	Id          openapi_types.UUID `json:"id"`
 
 	// Name Project name. Must be unique across all projects.
-	Name *string `json:"name,omitempty"`
+	Name string `json:"name"`
 }
 
-// GetProjectParams defines parameters for GetProject.
-type GetProjectParams struct {
-	// ProjectName The name of the project to retrieve.
-	ProjectName string `json:"projectName"`
+// ProjectInput defines model for ProjectInput.
+type ProjectInput struct {
+	// Created When the project was created. Defaults to now for new projects and is kept unchanged for existing ones.
+	Created *time.Time `json:"created,omitempty"`
+
+	// Description Project description
+	Description *string `json:"description,omitempty"`
+
+	// Id The ID of the project to create or rename.
+	Id *openapi_types.UUID `json:"id,omitempty"`
+}
+
+// Status Where a task stands. Completed and cancelled tasks are finished.
+type Status string
+
+// StatusChange defines model for StatusChange.
+type StatusChange struct {
+	// Status Where a task stands. Completed and cancelled tasks are finished.
+	Status Status `json:"status"`
+}
+
+// Task defines model for Task.
+type Task struct {
+	Completed *time.Time `json:"completed,omitempty"`
+	Created   time.Time  `json:"created"`
+
+	// Description Task description
+	Description *string `json:"description,omitempty"`
+
+	// DueDate The day the task is due, in dueZone.
+	DueDate *openapi_types.Date `json:"dueDate,omitempty"`
+
+	// DueTime The time of day the task is due, in dueZone. Without one the task is due any time on dueDate.
+	DueTime *string `json:"dueTime,omitempty"`
+
+	// DueZone The IANA time zone of dueDate and dueTime, UTC when left out.
+	DueZone *string            `json:"dueZone,omitempty"`
+	Id      openapi_types.UUID `json:"id"`
+
+	// Name Task name. Names do not need to be unique.
+	Name string `json:"name"`
+
+	// ParentId The task this task is a subtask of.
+	ParentId *openapi_types.UUID `json:"parentId,omitempty"`
+
+	// Priority Task priority.
+	Priority Priority `json:"priority"`
+
+	// ProjectId The project the task belongs to.
+	ProjectId *openapi_types.UUID `json:"projectId,omitempty"`
+
+	// Recurrence The RRULE the task repeats by. COUNT is the number of occurrences left, including this one.
+	Recurrence *string `json:"recurrence,omitempty"`
+
+	// Status Where a task stands. Completed and cancelled tasks are finished.
+	Status Status `json:"status"`
+
+	// Transitions Every change of status, oldest first.
+	Transitions *[]Transition `json:"transitions,omitempty"`
+}
+
+// TaskCount defines model for TaskCount.
+type TaskCount struct {
+	Count int `json:"count"`
+}
+
+// TaskInput defines model for TaskInput.
+type TaskInput struct {
+	// Completed When the task was completed. Only completed tasks have one, status defaults to completed when it is set and to open otherwise. Only read when the task is created, an existing task keeps its own.
+	Completed *time.Time `json:"completed,omitempty"`
+
+	// Created When the task was created. Defaults to now for new tasks and is kept unchanged for existing ones.
+	Created *time.Time `json:"created,omitempty"`
+
+	// Description Task description
+	Description *string `json:"description,omitempty"`
+
+	// DueDate The day the task is due, in dueZone.
+	DueDate *openapi_types.Date `json:"dueDate,omitempty"`
+
+	// DueTime The time of day the task is due, in dueZone. Without one the task is due any time on dueDate.
+	DueTime *string `json:"dueTime,omitempty"`
+
+	// DueZone The IANA time zone of dueDate and dueTime, UTC when left out.
+	DueZone *string `json:"dueZone,omitempty"`
+
+	// Name Task name. Names do not need to be unique.
+	Name string `json:"name"`
+
+	// ParentId The task this task is a subtask of.
+	ParentId *openapi_types.UUID `json:"parentId,omitempty"`
+
+	// Priority Task priority.
+	Priority *Priority `json:"priority,omitempty"`
+
+	// ProjectId The project the task belongs to.
+	ProjectId *openapi_types.UUID `json:"projectId,omitempty"`
+
+	// Recurrence Repeats the task from its due date, as an RFC 5545 RRULE limited to FREQ, INTERVAL, BYDAY, UNTIL and COUNT, for example FREQ=WEEKLY;BYDAY=MO,TH. Requires a due date.
+	Recurrence *string `json:"recurrence,omitempty"`
+
+	// Status Where a task stands. Completed and cancelled tasks are finished.
+	Status *Status `json:"status,omitempty"`
+
+	// Transitions Ignored, the server keeps the history of every task itself. Statuses change through /tasks/{id}/complete, /tasks/{id}/status and /tasks/{id}/reopen.
+	Transitions *[]Transition `json:"transitions,omitempty"`
+}
+
+// TaskParent defines model for TaskParent.
+type TaskParent struct {
+	// ParentId The task to move the task under. Leave out to make it a top-level task.
+	ParentId *openapi_types.UUID `json:"parentId,omitempty"`
+}
+
+// TaskProgress defines model for TaskProgress.
+type TaskProgress struct {
+	// Completed How many of the direct subtasks are completed.
+	Completed int `json:"completed"`
+
+	// Total How many direct subtasks the task has, not counting cancelled ones.
+	Total int `json:"total"`
 }
 
-// PostProjectJSONRequestBody defines body for PostProject for application/json ContentType.
-type PostProjectJSONRequestBody = Project
+// Transition defines model for Transition.
+type Transition struct {
+	At time.Time `json:"at"`
+
+	// By Who made the change, left out when it isn't known.
+	By *string `json:"by,omitempty"`
+
+	// From Where a task stands. Completed and cancelled tasks are finished.
+	From Status `json:"from"`
+
+	// To Where a task stands. Completed and cancelled tasks are finished.
+	To Status `json:"to"`
+}
+
+// Actor defines model for Actor.
+type Actor = string
+
+// TimeZone defines model for TimeZone.
+type TimeZone = string
+
+// ListProjectsParams defines parameters for ListProjects.
+type ListProjectsParams struct {
+	// Id Only return the project with this ID.
+	Id *openapi_types.UUID `form:"id,omitempty" json:"id,omitempty"`
+}
+
+// ListTasksParams defines parameters for ListTasks.
+type ListTasksParams struct {
+	// Limit The largest number of tasks to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor The X-Next-Cursor of the previous page.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Name Only return tasks with this name.
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+
+	// DueOn Only return tasks due on this day.
+	DueOn *openapi_types.Date `form:"dueOn,omitempty" json:"dueOn,omitempty"`
+
+	// Undated Only return tasks without a due date.
+	Undated *bool `form:"undated,omitempty" json:"undated,omitempty"`
+
+	// DueFrom Only return tasks due on or after this day, ordered by due date.
+	DueFrom *openapi_types.Date `form:"dueFrom,omitempty" json:"dueFrom,omitempty"`
+
+	// DueTo Only return tasks due on or before this day, ordered by due date.
+	DueTo *openapi_types.Date `form:"dueTo,omitempty" json:"dueTo,omitempty"`
+
+	// ProjectId Only return tasks in this project.
+	ProjectId *openapi_types.UUID `form:"projectId,omitempty" json:"projectId,omitempty"`
+
+	// Tz The IANA time zone, such as America/New_York, that days given or implied by the request are in. A task is due on a day when its due date falls between the midnights starting and ending the day in this time zone.
+	Tz *TimeZone `form:"tz,omitempty" json:"tz,omitempty"`
+}
+
+// ListOverdueTasksParams defines parameters for ListOverdueTasks.
+type ListOverdueTasksParams struct {
+	// Tz The IANA time zone, such as America/New_York, that days given or implied by the request are in. A task is due on a day when its due date falls between the midnights starting and ending the day in this time zone.
+	Tz *TimeZone `form:"tz,omitempty" json:"tz,omitempty"`
+}
+
+// SearchTasksParams defines parameters for SearchTasks.
+type SearchTasksParams struct {
+	// Completed Only return finished tasks, completed or cancelled, when true, or unfinished tasks when false.
+	Completed *bool `form:"completed,omitempty" json:"completed,omitempty"`
+
+	// Status Comma separated statuses, only return tasks in one of them.
+	Status *[]Status `form:"status,omitempty" json:"status,omitempty"`
+
+	// MinPriority Only return tasks with at least this priority.
+	MinPriority *Priority `form:"minPriority,omitempty" json:"minPriority,omitempty"`
+
+	// MaxPriority Only return tasks with at most this priority.
+	MaxPriority *Priority `form:"maxPriority,omitempty" json:"maxPriority,omitempty"`
+
+	// DueFrom Only return tasks due on or after this day.
+	DueFrom *openapi_types.Date `form:"dueFrom,omitempty" json:"dueFrom,omitempty"`
+
+	// DueTo Only return tasks due on or before this day.
+	DueTo *openapi_types.Date `form:"dueTo,omitempty" json:"dueTo,omitempty"`
+
+	// CreatedFrom Only return tasks created at or after this time.
+	CreatedFrom *time.Time `form:"createdFrom,omitempty" json:"createdFrom,omitempty"`
+
+	// CreatedTo Only return tasks created before this time.
+	CreatedTo *time.Time `form:"createdTo,omitempty" json:"createdTo,omitempty"`
+
+	// ProjectId Only return tasks in this project.
+	ProjectId *openapi_types.UUID `form:"projectId,omitempty" json:"projectId,omitempty"`
+
+	// Q Only return tasks whose name or description contains this text, ignoring case.
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Sort Comma separated sort keys, any of title, dueDate, priority and created. Prefix a key with - to sort in descending order. Tasks without a due date are last either way.
+	Sort *[]string `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Tz The IANA time zone, such as America/New_York, that days given or implied by the request are in. A task is due on a day when its due date falls between the midnights starting and ending the day in this time zone.
+	Tz *TimeZone `form:"tz,omitempty" json:"tz,omitempty"`
+}
+
+// ListUpcomingTasksParams defines parameters for ListUpcomingTasks.
+type ListUpcomingTasksParams struct {
+	// Days How many days after today to include.
+	Days *int `form:"days,omitempty" json:"days,omitempty"`
+
+	// IncludeCompleted Include finished tasks, completed or cancelled.
+	IncludeCompleted *bool `form:"includeCompleted,omitempty" json:"includeCompleted,omitempty"`
+
+	// Tz The IANA time zone, such as America/New_York, that days given or implied by the request are in. A task is due on a day when its due date falls between the midnights starting and ending the day in this time zone.
+	Tz *TimeZone `form:"tz,omitempty" json:"tz,omitempty"`
+}
+
+// DeleteTaskParams defines parameters for DeleteTask.
+type DeleteTaskParams struct {
+	// Subtasks What to do with the task's subtasks.
+	Subtasks *DeleteTaskParamsSubtasks `form:"subtasks,omitempty" json:"subtasks,omitempty"`
+}
+
+// DeleteTaskParamsSubtasks defines parameters for DeleteTask.
+type DeleteTaskParamsSubtasks string
+
+// CompleteTaskParams defines parameters for CompleteTask.
+type CompleteTaskParams struct {
+	// XActor Who is making the change, recorded with it in the task's transitions.
+	XActor *Actor `json:"X-Actor,omitempty"`
+}
+
+// ReopenTaskParams defines parameters for ReopenTask.
+type ReopenTaskParams struct {
+	// XActor Who is making the change, recorded with it in the task's transitions.
+	XActor *Actor `json:"X-Actor,omitempty"`
+}
+
+// TransitionTaskParams defines parameters for TransitionTask.
+type TransitionTaskParams struct {
+	// XActor Who is making the change, recorded with it in the task's transitions.
+	XActor *Actor `json:"X-Actor,omitempty"`
+}
+
+// PutProjectJSONRequestBody defines body for PutProject for application/json ContentType.
+type PutProjectJSONRequestBody = ProjectInput
+
+// CreateTaskJSONRequestBody defines body for CreateTask for application/json ContentType.
+type CreateTaskJSONRequestBody = TaskInput
+
+// PutTaskJSONRequestBody defines body for PutTask for application/json ContentType.
+type PutTaskJSONRequestBody = TaskInput
+
+// MoveTaskJSONRequestBody defines body for MoveTask for application/json ContentType.
+type MoveTaskJSONRequestBody = TaskParent
+
+// TransitionTaskJSONRequestBody defines body for TransitionTask for application/json ContentType.
+type TransitionTaskJSONRequestBody = StatusChange
+
+// CreateSubtaskJSONRequestBody defines body for CreateSubtask for application/json ContentType.
+type CreateSubtaskJSONRequestBody = TaskInput
 
 // Getter for additional properties for ProblemDetails. Returns the specified
 // element and whether it was found
@@ -116,7 +422,7 @@ func (a *ProblemDetails) UnmarshalJSON(b []byte) error {
 			var fieldVal interface{}
 			err := json.Unmarshal(fieldBuf, &fieldVal)
 			if err != nil {
-				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+				return fmt.Errorf("error unmarshalling field %s: %w", fieldName, err)
 			}
 			a.AdditionalProperties[fieldName] = fieldVal
 		}
@@ -0,0 +1,611 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	"github.com/google/uuid"
+	"github.com/peschkaj/togo"
+	"github.com/peschkaj/togo/store"
+	"net/http"
+	"time"
+)
+
+// RemoteStore implements store.Store on top of a togo server, so code written
+// against a local store can use a remote one unchanged. Problems reported by
+// the server are mapped back onto the store error taxonomy.
+type RemoteStore struct {
+	client *ClientWithResponses
+}
+
+var _ store.Store = (*RemoteStore)(nil)
+
+// NewRemoteStore creates a store backed by the togo server at server, for
+// example "http://localhost:8080".
+func NewRemoteStore(server string, opts ...ClientOption) (*RemoteStore, error) {
+	client, err := NewClientWithResponses(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteStore{client: client}, nil
+}
+
+// ProblemError is the error reported by the server, along with the HTTP status
+// of the response. Problem is nil when the response didn't describe the
+// problem.
+type ProblemError struct {
+	StatusCode int
+	Problem    *ProblemDetails
+}
+
+func (e *ProblemError) Error() string {
+	if e.Problem != nil && e.Problem.Detail != nil {
+		return fmt.Sprintf("togo server: %d: %s", e.StatusCode, *e.Problem.Detail)
+	}
+	return fmt.Sprintf("togo server: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// responseError maps an unexpected response onto the store error taxonomy.
+func responseError(op string, status int, problem *ProblemDetails) error {
+	err := &ProblemError{StatusCode: status, Problem: problem}
+	switch status {
+	case http.StatusNotFound:
+		return store.NotFound(op, err)
+	case http.StatusConflict:
+		return store.Conflict(op, err)
+	case http.StatusBadRequest:
+		return store.Invalid(op, err)
+	case http.StatusGatewayTimeout:
+		return store.Timeout(op, err)
+	}
+	return err
+}
+
+// AddOrUpdateTask saves t with PUT. The server keeps the history of every
+// task, so t's transitions aren't sent and a new task starts without any.
+func (rs *RemoteStore) AddOrUpdateTask(ctx context.Context, t togo.Task) error {
+	const op = "AddOrUpdateTask"
+	if err := store.ValidateTask(op, t); err != nil {
+		return err
+	}
+
+	resp, err := rs.client.PutTaskWithResponse(ctx, t.ID, toTaskInput(t))
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil && resp.JSON201 == nil {
+		return responseError(op, resp.StatusCode(), resp.JSONDefault)
+	}
+	return nil
+}
+
+func (rs *RemoteStore) RemoveTask(ctx context.Context, id uuid.UUID) error {
+	return rs.removeTask(ctx, "RemoveTask", id, store.OrphanSubtasks)
+}
+
+func (rs *RemoteStore) RemoveTaskWith(ctx context.Context, id uuid.UUID, rule store.RemoveRule) error {
+	return rs.removeTask(ctx, "RemoveTaskWith", id, rule)
+}
+
+func (rs *RemoteStore) removeTask(ctx context.Context, op string, id uuid.UUID, rule store.RemoveRule) error {
+	if err := store.ValidateRemoveRule(op, rule); err != nil {
+		return err
+	}
+
+	subtasks := Orphan
+	switch rule {
+	case store.RemoveSubtasks:
+		subtasks = Remove
+	case store.RestrictSubtasks:
+		subtasks = Restrict
+	}
+
+	resp, err := rs.client.DeleteTaskWithResponse(ctx, id, &DeleteTaskParams{Subtasks: &subtasks})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusNoContent {
+		return responseError(op, resp.StatusCode(), resp.JSONDefault)
+	}
+	return nil
+}
+
+func (rs *RemoteStore) Subtasks(ctx context.Context, id uuid.UUID) ([]togo.Task, error) {
+	resp, err := rs.client.ListSubtasksWithResponse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, responseError("Subtasks", resp.StatusCode(), resp.JSONDefault)
+	}
+	return fromAPITasks(*resp.JSON200), nil
+}
+
+func (rs *RemoteStore) MoveTask(ctx context.Context, id uuid.UUID, parent *uuid.UUID) error {
+	resp, err := rs.client.MoveTaskWithResponse(ctx, id, TaskParent{ParentId: parent})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return responseError("MoveTask", resp.StatusCode(), resp.JSONDefault)
+	}
+	return nil
+}
+
+// CompleteTask fetches the next occurrence named in the X-Next-Occurrence
+// header of the response, when there is one.
+func (rs *RemoteStore) CompleteTask(ctx context.Context, id uuid.UUID) (store.Completion, error) {
+	const op = "CompleteTask"
+	resp, err := rs.client.CompleteTaskWithResponse(ctx, id, &CompleteTaskParams{XActor: actorParam(ctx)})
+	if err != nil {
+		return store.Completion{}, err
+	}
+	if resp.JSON200 == nil {
+		return store.Completion{}, responseError(op, resp.StatusCode(), resp.JSONDefault)
+	}
+	return rs.completion(ctx, *resp.JSON200, resp.HTTPResponse.Header)
+}
+
+func (rs *RemoteStore) TransitionTask(ctx context.Context, id uuid.UUID, to togo.Status) (store.Completion, error) {
+	const op = "TransitionTask"
+	params := &TransitionTaskParams{XActor: actorParam(ctx)}
+	resp, err := rs.client.TransitionTaskWithResponse(ctx, id, params, TransitionTaskJSONRequestBody{Status: toAPIStatus(to)})
+	if err != nil {
+		return store.Completion{}, err
+	}
+	if resp.JSON200 == nil {
+		return store.Completion{}, responseError(op, resp.StatusCode(), resp.JSONDefault)
+	}
+	return rs.completion(ctx, *resp.JSON200, resp.HTTPResponse.Header)
+}
+
+func (rs *RemoteStore) ReopenTask(ctx context.Context, id uuid.UUID) (togo.Task, error) {
+	resp, err := rs.client.ReopenTaskWithResponse(ctx, id, &ReopenTaskParams{XActor: actorParam(ctx)})
+	if err != nil {
+		return togo.Task{}, err
+	}
+	if resp.JSON200 == nil {
+		return togo.Task{}, responseError("ReopenTask", resp.StatusCode(), resp.JSONDefault)
+	}
+	return fromAPITask(*resp.JSON200), nil
+}
+
+// actorParam sends the actor set on ctx as the X-Actor header, see
+// store.WithActor.
+func actorParam(ctx context.Context) *Actor {
+	actor := store.Actor(ctx)
+	if actor == "" {
+		return nil
+	}
+	return &actor
+}
+
+// completion reads the task returned by a change of status, along with the
+// next occurrence named in the response headers when one was created.
+func (rs *RemoteStore) completion(ctx context.Context, t Task, headers http.Header) (store.Completion, error) {
+	completion := store.Completion{Task: fromAPITask(t)}
+	if header := headers.Get("X-Next-Occurrence"); header != "" {
+		nextID, err := uuid.Parse(header)
+		if err != nil {
+			return store.Completion{}, fmt.Errorf("togo server: malformed X-Next-Occurrence header: %w", err)
+		}
+		next, err := rs.FindTask(ctx, nextID)
+		if err != nil {
+			return store.Completion{}, err
+		}
+		completion.Next = &next
+	}
+	return completion, nil
+}
+
+func (rs *RemoteStore) FindTask(ctx context.Context, id uuid.UUID) (togo.Task, error) {
+	resp, err := rs.client.GetTaskWithResponse(ctx, id)
+	if err != nil {
+		return togo.Task{}, err
+	}
+	if resp.JSON200 == nil {
+		return togo.Task{}, responseError("FindTask", resp.StatusCode(), resp.JSONDefault)
+	}
+	return fromAPITask(*resp.JSON200), nil
+}
+
+func (rs *RemoteStore) FindTasksByName(ctx context.Context, name string) ([]togo.Task, error) {
+	return rs.listTasks(ctx, "FindTasksByName", ListTasksParams{Name: &name})
+}
+
+func (rs *RemoteStore) FindByDueDate(ctx context.Context, dueDate *time.Time, loc *time.Location) ([]togo.Task, error) {
+	if dueDate == nil {
+		undated := true
+		return rs.listTasks(ctx, "FindByDueDate", ListTasksParams{Undated: &undated})
+	}
+	return rs.listTasks(ctx, "FindByDueDate", ListTasksParams{DueOn: &openapi_types.Date{Time: *dueDate}, Tz: zoneParam(loc)})
+}
+
+func (rs *RemoteStore) FindDueBetween(ctx context.Context, start, end time.Time, loc *time.Location) ([]togo.Task, error) {
+	return rs.listTasks(ctx, "FindDueBetween", ListTasksParams{
+		DueFrom: &openapi_types.Date{Time: start},
+		DueTo:   &openapi_types.Date{Time: end},
+		Tz:      zoneParam(loc),
+	})
+}
+
+func (rs *RemoteStore) OverdueTasks(ctx context.Context, loc *time.Location) ([]togo.Task, error) {
+	resp, err := rs.client.ListOverdueTasksWithResponse(ctx, &ListOverdueTasksParams{Tz: zoneParam(loc)})
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, responseError("OverdueTasks", resp.StatusCode(), resp.JSONDefault)
+	}
+	return fromAPITasks(*resp.JSON200), nil
+}
+
+func (rs *RemoteStore) Upcoming(ctx context.Context, window store.UpcomingWindow) ([]togo.Task, error) {
+	params := ListUpcomingTasksParams{Days: &window.Days, IncludeCompleted: &window.IncludeCompleted, Tz: zoneParam(window.Location)}
+	resp, err := rs.client.ListUpcomingTasksWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, responseError("Upcoming", resp.StatusCode(), resp.JSONDefault)
+	}
+	return fromAPITasks(*resp.JSON200), nil
+}
+
+func (rs *RemoteStore) Count(ctx context.Context) (int, error) {
+	resp, err := rs.client.CountTasksWithResponse(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if resp.JSON200 == nil {
+		return 0, responseError("Count", resp.StatusCode(), resp.JSONDefault)
+	}
+	return resp.JSON200.Count, nil
+}
+
+// All pages through every task, requesting the largest pages the server
+// allows.
+func (rs *RemoteStore) All(ctx context.Context) ([]togo.Task, error) {
+	tasks := []togo.Task{}
+	page := store.Page{Limit: store.MaxPageLimit}
+	for {
+		result, err := rs.listPage(ctx, "All", page)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, result.Tasks...)
+		if result.Next == "" {
+			return tasks, nil
+		}
+		page.Cursor = result.Next
+	}
+}
+
+func (rs *RemoteStore) ListTasks(ctx context.Context, page store.Page) (store.TaskPage, error) {
+	return rs.listPage(ctx, "ListTasks", page)
+}
+
+func (rs *RemoteStore) listPage(ctx context.Context, op string, page store.Page) (store.TaskPage, error) {
+	limit := page.Size()
+	params := ListTasksParams{Limit: &limit}
+	if page.Cursor != "" {
+		params.Cursor = &page.Cursor
+	}
+
+	resp, err := rs.client.ListTasksWithResponse(ctx, &params)
+	if err != nil {
+		return store.TaskPage{}, err
+	}
+	if resp.JSON200 == nil {
+		return store.TaskPage{}, responseError(op, resp.StatusCode(), resp.JSONDefault)
+	}
+	return store.TaskPage{
+		Tasks: fromAPITasks(*resp.JSON200),
+		Next:  resp.HTTPResponse.Header.Get("X-Next-Cursor"),
+	}, nil
+}
+
+func (rs *RemoteStore) QueryTasks(ctx context.Context, q store.Query) ([]togo.Task, error) {
+	const op = "QueryTasks"
+	if err := store.ValidateQuery(op, q); err != nil {
+		return nil, err
+	}
+
+	params := SearchTasksParams{
+		Completed:   q.Completed,
+		CreatedFrom: q.CreatedFrom,
+		CreatedTo:   q.CreatedTo,
+		ProjectId:   q.ProjectID,
+		Tz:          zoneParam(q.Location),
+	}
+	if len(q.Statuses) > 0 {
+		statuses := make([]Status, 0, len(q.Statuses))
+		for _, status := range q.Statuses {
+			statuses = append(statuses, toAPIStatus(status))
+		}
+		params.Status = &statuses
+	}
+	if q.MinPriority != nil {
+		p := toAPIPriority(*q.MinPriority)
+		params.MinPriority = &p
+	}
+	if q.MaxPriority != nil {
+		p := toAPIPriority(*q.MaxPriority)
+		params.MaxPriority = &p
+	}
+	if q.DueFrom != nil {
+		params.DueFrom = &openapi_types.Date{Time: *q.DueFrom}
+	}
+	if q.DueTo != nil {
+		params.DueTo = &openapi_types.Date{Time: *q.DueTo}
+	}
+	if q.Text != "" {
+		params.Q = &q.Text
+	}
+	if len(q.Sort) > 0 {
+		keys := make([]string, 0, len(q.Sort))
+		for _, key := range q.Sort {
+			keys = append(keys, key.String())
+		}
+		params.Sort = &keys
+	}
+
+	resp, err := rs.client.SearchTasksWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, responseError(op, resp.StatusCode(), resp.JSONDefault)
+	}
+	return fromAPITasks(*resp.JSON200), nil
+}
+
+func (rs *RemoteStore) listTasks(ctx context.Context, op string, params ListTasksParams) ([]togo.Task, error) {
+	resp, err := rs.client.ListTasksWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, responseError(op, resp.StatusCode(), resp.JSONDefault)
+	}
+	return fromAPITasks(*resp.JSON200), nil
+}
+
+func (rs *RemoteStore) AddOrUpdateProject(ctx context.Context, p togo.Project) error {
+	const op = "AddOrUpdateProject"
+	if err := store.ValidateProject(op, p); err != nil {
+		return err
+	}
+
+	input := ProjectInput{Id: &p.ID, Description: &p.Description, Created: &p.Created}
+	resp, err := rs.client.PutProjectWithResponse(ctx, p.Name, input)
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil && resp.JSON201 == nil {
+		return responseError(op, resp.StatusCode(), resp.JSONDefault)
+	}
+	return nil
+}
+
+// RemoveProject looks up the project's name, which the API uses to address
+// projects, before removing it.
+func (rs *RemoteStore) RemoveProject(ctx context.Context, id uuid.UUID) error {
+	project, err := rs.FindProject(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	resp, err := rs.client.DeleteProjectWithResponse(ctx, project.Name)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusNoContent {
+		return responseError("RemoveProject", resp.StatusCode(), resp.JSONDefault)
+	}
+	return nil
+}
+
+func (rs *RemoteStore) FindProject(ctx context.Context, id uuid.UUID) (togo.Project, error) {
+	const op = "FindProject"
+	resp, err := rs.client.ListProjectsWithResponse(ctx, &ListProjectsParams{Id: &id})
+	if err != nil {
+		return togo.Project{}, err
+	}
+	if resp.JSON200 == nil {
+		return togo.Project{}, responseError(op, resp.StatusCode(), resp.JSONDefault)
+	}
+	if len(*resp.JSON200) == 0 {
+		return togo.Project{}, store.NotFound(op, fmt.Errorf("project %v", id))
+	}
+	return fromAPIProject((*resp.JSON200)[0]), nil
+}
+
+func (rs *RemoteStore) FindProjectByName(ctx context.Context, name string) (togo.Project, error) {
+	resp, err := rs.client.GetProjectWithResponse(ctx, name)
+	if err != nil {
+		return togo.Project{}, err
+	}
+	if resp.JSON200 == nil {
+		return togo.Project{}, responseError("FindProjectByName", resp.StatusCode(), resp.JSONDefault)
+	}
+	return fromAPIProject(*resp.JSON200), nil
+}
+
+func (rs *RemoteStore) AllProjects(ctx context.Context) ([]togo.Project, error) {
+	resp, err := rs.client.ListProjectsWithResponse(ctx, &ListProjectsParams{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, responseError("AllProjects", resp.StatusCode(), resp.JSONDefault)
+	}
+
+	projects := make([]togo.Project, 0, len(*resp.JSON200))
+	for _, p := range *resp.JSON200 {
+		projects = append(projects, fromAPIProject(p))
+	}
+	return projects, nil
+}
+
+func (rs *RemoteStore) TasksInProject(ctx context.Context, id uuid.UUID) ([]togo.Task, error) {
+	return rs.listTasks(ctx, "TasksInProject", ListTasksParams{ProjectId: &id})
+}
+
+// zoneParam names loc for the tz parameter, nil leaves the server's default of
+// UTC.
+func zoneParam(loc *time.Location) *TimeZone {
+	if loc == nil {
+		return nil
+	}
+	name := loc.String()
+	return &name
+}
+
+func toTaskInput(t togo.Task) TaskInput {
+	priority := toAPIPriority(t.Priority)
+	status := toAPIStatus(t.Status)
+	input := TaskInput{
+		Name:        t.Name,
+		Description: &t.Description,
+		Priority:    &priority,
+		ProjectId:   t.ProjectID,
+		ParentId:    t.ParentID,
+		Created:     &t.Created,
+		Completed:   t.Completed,
+		Status:      &status,
+	}
+	if t.DueDate != nil {
+		input.DueDate = &openapi_types.Date{Time: *t.DueDate}
+		input.DueZone = zoneParam(t.DueDate.Location())
+		if t.HasDueTime {
+			dueTime := t.DueDate.Format(dueTimeLayout)
+			input.DueTime = &dueTime
+		}
+	}
+	if t.Recurrence != nil {
+		recurrence := t.Recurrence.String()
+		input.Recurrence = &recurrence
+	}
+	return input
+}
+
+func fromAPITask(t Task) togo.Task {
+	task := togo.Task{
+		ID:        t.Id,
+		Name:      t.Name,
+		Priority:  fromAPIPriority(t.Priority),
+		ProjectID: t.ProjectId,
+		ParentID:  t.ParentId,
+		Created:   t.Created,
+		Completed: t.Completed,
+		Status:    fromAPIStatus(t.Status),
+	}
+	if t.Transitions != nil {
+		for _, transition := range *t.Transitions {
+			var by string
+			if transition.By != nil {
+				by = *transition.By
+			}
+			task.Transitions = append(task.Transitions, togo.Transition{
+				From: fromAPIStatus(transition.From),
+				To:   fromAPIStatus(transition.To),
+				At:   transition.At,
+				By:   by,
+			})
+		}
+	}
+	if t.Description != nil {
+		task.Description = *t.Description
+	}
+	if t.DueDate != nil {
+		task.DueDate = fromAPIDueDate(*t.DueDate, t.DueTime, t.DueZone)
+		task.HasDueTime = t.DueTime != nil
+	}
+	// the server only returns rules it was able to parse
+	if t.Recurrence != nil {
+		if recurrence, err := togo.ParseRecurrence(*t.Recurrence); err == nil {
+			task.Recurrence = &recurrence
+		}
+	}
+	return task
+}
+
+// dueTimeLayout formats due times, always as hours and minutes.
+const dueTimeLayout = "15:04"
+
+// fromAPIDueDate puts a due date back together from its parts. The server
+// only returns zones and times it was able to parse.
+func fromAPIDueDate(date openapi_types.Date, dueTime *string, dueZone *TimeZone) *time.Time {
+	zone := time.UTC
+	if dueZone != nil {
+		if loc, err := togo.LoadZone(*dueZone); err == nil {
+			zone = loc
+		}
+	}
+
+	var hh, mm int
+	if dueTime != nil {
+		if parsed, err := time.Parse(dueTimeLayout, *dueTime); err == nil {
+			hh, mm = parsed.Hour(), parsed.Minute()
+		}
+	}
+
+	yyyy, month, dd := date.Date()
+	due := time.Date(yyyy, month, dd, hh, mm, 0, 0, zone)
+	return &due
+}
+
+func fromAPITasks(ts []Task) []togo.Task {
+	tasks := make([]togo.Task, 0, len(ts))
+	for _, t := range ts {
+		tasks = append(tasks, fromAPITask(t))
+	}
+	return tasks
+}
+
+func fromAPIProject(p Project) togo.Project {
+	project := togo.Project{ID: p.Id, Name: p.Name, Created: p.Created}
+	if p.Description != nil {
+		project.Description = *p.Description
+	}
+	return project
+}
+
+func toAPIPriority(p togo.Priority) Priority {
+	switch p {
+	case togo.Low:
+		return Low
+	case togo.Medium:
+		return Medium
+	case togo.High:
+		return High
+	default:
+		return None
+	}
+}
+
+func fromAPIPriority(p Priority) togo.Priority {
+	switch p {
+	case Low:
+		return togo.Low
+	case Medium:
+		return togo.Medium
+	case High:
+		return togo.High
+	default:
+		return togo.None
+	}
+}
+
+// toAPIStatus relies on the API using the same names as togo.Status.String.
+func toAPIStatus(s togo.Status) Status {
+	return Status(s.String())
+}
+
+// fromAPIStatus reads a status returned by the server, which only returns the
+// statuses it knows.
+func fromAPIStatus(s Status) togo.Status {
+	status, _ := togo.ParseStatus(string(s))
+	return status
+}
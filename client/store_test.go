@@ -0,0 +1,57 @@
+package client
+
+import (
+	"errors"
+	"github.com/peschkaj/togo"
+	"github.com/peschkaj/togo/server"
+	"github.com/peschkaj/togo/store"
+	"github.com/peschkaj/togo/store/memory"
+	"github.com/peschkaj/togo/store/storetest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRemoteStoreConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T, clock togo.Clock) store.Store {
+		ts := httptest.NewServer(server.NewServer(memory.NewMemoryStore(memory.WithClock(clock))).Handler())
+		t.Cleanup(ts.Close)
+
+		rs, err := NewRemoteStore(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rs
+	})
+}
+
+func TestProblemsAreMapped(t *testing.T) {
+	testCases := []struct {
+		status int
+		kind   error
+	}{
+		{status: http.StatusNotFound, kind: store.ErrNotFound},
+		{status: http.StatusConflict, kind: store.ErrConflict},
+		{status: http.StatusBadRequest, kind: store.ErrInvalid},
+		{status: http.StatusGatewayTimeout, kind: store.ErrTimeout},
+	}
+
+	for _, testCase := range testCases {
+		detail := "detail"
+		err := responseError("Op", testCase.status, &ProblemDetails{Detail: &detail})
+		if !errors.Is(err, testCase.kind) {
+			t.Errorf("expected %d to map to %v, found %v", testCase.status, testCase.kind, err)
+		}
+
+		var problem *ProblemError
+		if !errors.As(err, &problem) || problem.StatusCode != testCase.status {
+			t.Errorf("the server's problem is not reachable from %v", err)
+		}
+	}
+
+	err := responseError("Op", http.StatusInternalServerError, nil)
+	var problem *ProblemError
+	if !errors.As(err, &problem) {
+		t.Errorf("expected a problem error, found %v", err)
+	}
+}
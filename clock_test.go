@@ -0,0 +1,25 @@
+package togo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClockOnlyMovesWhenTold(t *testing.T) {
+	start := time.Date(2030, time.March, 14, 9, 30, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	if now := clock.Now(); !now.Equal(start) {
+		t.Errorf("expected %v found %v", start, now)
+	}
+
+	clock.Advance(90 * time.Minute)
+	if now, want := clock.Now(), start.Add(90*time.Minute); !now.Equal(want) {
+		t.Errorf("expected %v found %v", want, now)
+	}
+
+	clock.Set(start.AddDate(0, 0, -1))
+	if now, want := clock.Now(), start.AddDate(0, 0, -1); !now.Equal(want) {
+		t.Errorf("expected %v found %v", want, now)
+	}
+}
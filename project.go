@@ -0,0 +1,23 @@
+package togo
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Project groups related tasks. Project names are unique.
+type Project struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	Created     time.Time
+}
+
+func NewProject(name, description string) Project {
+	return NewProjectAt(SystemClock.Now(), name, description)
+}
+
+// NewProjectAt creates a project like NewProject, created at created.
+func NewProjectAt(created time.Time, name, description string) Project {
+	return Project{ID: uuid.New(), Name: name, Description: description, Created: created}
+}
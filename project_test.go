@@ -0,0 +1,38 @@
+package togo
+
+import (
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestNewProjectsHaveDistinctIDs(t *testing.T) {
+	first := NewProject("name", "description")
+	second := NewProject("name", "description")
+
+	if first.ID == uuid.Nil || second.ID == uuid.Nil {
+		t.Error("a new project should have an ID")
+	}
+
+	if first.ID == second.ID {
+		t.Error("new projects should not share an ID")
+	}
+}
+
+func TestTaskCanBeAddedToProject(t *testing.T) {
+	project := NewProject("project", "description")
+	task := NewTask("task", "description")
+
+	if task.InProject(project) {
+		t.Error("a new task should not be in a project")
+	}
+
+	task.AddToProject(project)
+	if !task.InProject(project) {
+		t.Error("task was not added to the project")
+	}
+
+	task.RemoveFromProject()
+	if task.InProject(project) || task.ProjectID != nil {
+		t.Error("task was not removed from the project")
+	}
+}
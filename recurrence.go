@@ -0,0 +1,360 @@
+package togo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is how often a recurring task repeats, before Interval is applied.
+type Frequency int
+
+const (
+	Daily Frequency = iota + 1
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[Frequency]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+func (f Frequency) String() string {
+	if name, ok := frequencyNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("Frequency(%d)", int(f))
+}
+
+// RecurringDay selects a day of the week. Nth picks a single occurrence of the
+// weekday within the month of a monthly recurrence, 1 for the first and -1 for
+// the last; zero selects every occurrence.
+type RecurringDay struct {
+	Weekday time.Weekday
+	Nth     int
+}
+
+var weekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func (d RecurringDay) String() string {
+	if d.Nth != 0 {
+		return strconv.Itoa(d.Nth) + weekdayCodes[d.Weekday]
+	}
+	return weekdayCodes[d.Weekday]
+}
+
+// Recurrence repeats a task, following the subset of RFC 5545 recurrence rules
+// made up of FREQ, INTERVAL, BYDAY, UNTIL and COUNT. The task's due date is the
+// first occurrence; completing an occurrence creates the next one.
+type Recurrence struct {
+	Frequency Frequency
+	// Interval is the number of frequency periods between occurrences, zero
+	// and one both repeat every period.
+	Interval int
+	// ByDay limits the occurrences to the given days. Weekly rules repeat on
+	// each day in the week, monthly rules on each matching day in the month and
+	// daily rules skip any other day. Yearly rules don't support ByDay.
+	ByDay []RecurringDay
+	// Until is the last day an occurrence can fall on.
+	Until *time.Time
+	// Count is the number of occurrences left, including the current one.
+	// Zero repeats until Until, or forever.
+	Count int
+}
+
+// maxSkippedPeriods bounds the search for the next occurrence, so that rules
+// which can never match again, like the fifth Monday of every twelfth month
+// starting in a month without one, end instead of looping.
+const maxSkippedPeriods = 1000
+
+// Validate reports rules outside of the supported subset.
+func (r Recurrence) Validate() error {
+	if _, ok := frequencyNames[r.Frequency]; !ok {
+		return errors.New("unknown recurrence frequency")
+	}
+	if r.Interval < 0 {
+		return errors.New("recurrence interval must not be negative")
+	}
+	if r.Count < 0 {
+		return errors.New("recurrence count must not be negative")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return errors.New("recurrence can't have both a count and an end date")
+	}
+	if r.Frequency == Yearly && len(r.ByDay) > 0 {
+		return errors.New("yearly recurrence doesn't support days of the week")
+	}
+	for _, day := range r.ByDay {
+		if day.Weekday < time.Sunday || day.Weekday > time.Saturday {
+			return fmt.Errorf("unknown weekday %d", day.Weekday)
+		}
+		if day.Nth != 0 && r.Frequency != Monthly {
+			return errors.New("only monthly recurrence can pick the nth weekday")
+		}
+		if day.Nth < -5 || day.Nth > 5 {
+			return fmt.Errorf("there is no weekday number %d in a month", day.Nth)
+		}
+	}
+	return nil
+}
+
+// String formats r as an RRULE value, for example
+// "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH".
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + r.Frequency.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			days = append(days, day.String())
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.Format(rruleDate))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// RRULE dates, UNTIL is also accepted as a UTC date-time.
+const (
+	rruleDate     = "20060102"
+	rruleDateTime = "20060102T150405Z"
+)
+
+// ParseRecurrence reads an RRULE value, with or without the "RRULE:" prefix.
+// Parts outside of the supported subset are rejected.
+func ParseRecurrence(rule string) (Recurrence, error) {
+	var r Recurrence
+	for _, part := range strings.Split(strings.TrimPrefix(rule, "RRULE:"), ";") {
+		name, value, found := strings.Cut(part, "=")
+		if !found {
+			return Recurrence{}, fmt.Errorf("malformed recurrence rule part %q", part)
+		}
+
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.Frequency, err = parseFrequency(value)
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseUntil(value)
+			r.Until = &until
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+		default:
+			err = errors.New("unsupported")
+		}
+		if err != nil {
+			return Recurrence{}, fmt.Errorf("recurrence rule part %q: %w", part, err)
+		}
+	}
+
+	if err := r.Validate(); err != nil {
+		return Recurrence{}, err
+	}
+	return r, nil
+}
+
+func parseFrequency(value string) (Frequency, error) {
+	for f, name := range frequencyNames {
+		if strings.EqualFold(name, value) {
+			return f, nil
+		}
+	}
+	return 0, errors.New("unknown frequency")
+}
+
+func parseByDay(value string) ([]RecurringDay, error) {
+	var days []RecurringDay
+	for _, code := range strings.Split(value, ",") {
+		code = strings.ToUpper(code)
+		if len(code) < 2 {
+			return nil, fmt.Errorf("unknown weekday %q", code)
+		}
+
+		day := RecurringDay{Weekday: -1}
+		for weekday, weekdayCode := range weekdayCodes {
+			if strings.HasSuffix(code, weekdayCode) {
+				day.Weekday = time.Weekday(weekday)
+			}
+		}
+		if day.Weekday < 0 {
+			return nil, fmt.Errorf("unknown weekday %q", code)
+		}
+		if nth := code[:len(code)-2]; nth != "" {
+			n, err := strconv.Atoi(nth)
+			if err != nil || n == 0 {
+				return nil, fmt.Errorf("unknown weekday %q", code)
+			}
+			day.Nth = n
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if until, err := time.Parse(rruleDate, value); err == nil {
+		return until, nil
+	}
+	return time.Parse(rruleDateTime, value)
+}
+
+// Next returns the first occurrence after the occurrence due on the day of
+// due, at the same time of day in due's location. It returns false once the
+// rule is exhausted.
+func (r Recurrence) Next(due time.Time) (time.Time, bool) {
+	if r.Count == 1 {
+		return time.Time{}, false
+	}
+
+	day := date(due.Date())
+	next, ok := r.after(day)
+	if !ok || (r.Until != nil && next.After(date(r.Until.Date()))) {
+		return time.Time{}, false
+	}
+	yyyy, mm, dd := next.Date()
+	return time.Date(yyyy, mm, dd, due.Hour(), due.Minute(), due.Second(), due.Nanosecond(), due.Location()), true
+}
+
+// following returns the rule for the occurrence after the one r belongs to.
+func (r Recurrence) following() Recurrence {
+	next := r
+	if next.Count > 0 {
+		next.Count--
+	}
+	next.ByDay = append([]RecurringDay(nil), r.ByDay...)
+	return next
+}
+
+func (r Recurrence) interval() int {
+	if r.Interval < 1 {
+		return 1
+	}
+	return r.Interval
+}
+
+func (r Recurrence) after(day time.Time) (time.Time, bool) {
+	interval := r.interval()
+
+	switch r.Frequency {
+	case Daily:
+		for i := 1; i <= maxSkippedPeriods; i++ {
+			next := day.AddDate(0, 0, i*interval)
+			if r.onAnyDay(next) {
+				return next, true
+			}
+		}
+
+	case Weekly:
+		if len(r.ByDay) == 0 {
+			return day.AddDate(0, 0, 7*interval), true
+		}
+		// weeks start on Monday, as they do by default in RFC 5545
+		weekStart := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		for next := day.AddDate(0, 0, 1); next.Before(weekStart.AddDate(0, 0, 7)); next = next.AddDate(0, 0, 1) {
+			if r.onAnyDay(next) {
+				return next, true
+			}
+		}
+		weekStart = weekStart.AddDate(0, 0, 7*interval)
+		for next := weekStart; next.Before(weekStart.AddDate(0, 0, 7)); next = next.AddDate(0, 0, 1) {
+			if r.onAnyDay(next) {
+				return next, true
+			}
+		}
+
+	case Monthly:
+		yyyy, mm, _ := day.Date()
+		for i := 0; i <= maxSkippedPeriods; i++ {
+			for _, next := range r.daysInMonth(day, yyyy, mm+time.Month(i*interval)) {
+				if next.After(day) {
+					return next, true
+				}
+			}
+		}
+
+	case Yearly:
+		yyyy, mm, dd := day.Date()
+		for i := 1; i <= maxSkippedPeriods; i++ {
+			// skip years without the day, like February 29th
+			if next := date(yyyy+i*interval, mm, dd); next.Day() == dd {
+				return next, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// onAnyDay reports whether day is one of the days in ByDay, or true when there
+// are none.
+func (r Recurrence) onAnyDay(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, d := range r.ByDay {
+		if d.Weekday == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// daysInMonth returns the days of a monthly rule in the given month, in
+// order. Without ByDay that's the day of the month of the first occurrence,
+// when the month has it.
+func (r Recurrence) daysInMonth(first time.Time, yyyy int, mm time.Month) []time.Time {
+	start := date(yyyy, mm, 1)
+	end := start.AddDate(0, 1, 0)
+
+	if len(r.ByDay) == 0 {
+		if next := date(yyyy, mm, first.Day()); next.Before(end) {
+			return []time.Time{next}
+		}
+		return nil
+	}
+
+	var days []time.Time
+	for _, d := range r.ByDay {
+		var matches []time.Time
+		for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+			if day.Weekday() == d.Weekday {
+				matches = append(matches, day)
+			}
+		}
+
+		switch {
+		case d.Nth == 0:
+			days = append(days, matches...)
+		case d.Nth > 0 && d.Nth <= len(matches):
+			days = append(days, matches[d.Nth-1])
+		case d.Nth < 0 && -d.Nth <= len(matches):
+			days = append(days, matches[len(matches)+d.Nth])
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days
+}
+
+func date(yyyy int, mm time.Month, dd int) time.Time {
+	return time.Date(yyyy, mm, dd, 0, 0, 0, 0, time.UTC)
+}
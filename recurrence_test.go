@@ -0,0 +1,135 @@
+package togo
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParseRecurrence(t *testing.T, rule string) Recurrence {
+	t.Helper()
+	r, err := ParseRecurrence(rule)
+	if err != nil {
+		t.Fatalf("%q: %v", rule, err)
+	}
+	return r
+}
+
+func TestRecurrenceRoundTrip(t *testing.T) {
+	for _, rule := range []string{
+		"FREQ=DAILY",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+		"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+		"FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20240331",
+		"FREQ=YEARLY;INTERVAL=4",
+	} {
+		if formatted := mustParseRecurrence(t, rule).String(); formatted != rule {
+			t.Errorf("expected %q found %q", rule, formatted)
+		}
+	}
+
+	r := mustParseRecurrence(t, "RRULE:freq=weekly;until=20240331T120000Z")
+	if r.Frequency != Weekly || r.Until == nil || !r.Until.Equal(time.Date(2024, time.March, 31, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected rule %+v", r)
+	}
+}
+
+func TestUnsupportedRecurrencesAreRejected(t *testing.T) {
+	for _, rule := range []string{
+		"",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=-1",
+		"FREQ=DAILY;COUNT=2;UNTIL=20240101",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=YEARLY;BYDAY=MO",
+		"FREQ=DAILY;BYSETPOS=1",
+		"FREQ=DAILY;UNTIL=tomorrow",
+	} {
+		if _, err := ParseRecurrence(rule); err == nil {
+			t.Errorf("%q: expected an error", rule)
+		}
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	testCases := []struct {
+		rule     string
+		first    time.Time
+		expected []time.Time
+	}{
+		{"FREQ=DAILY;INTERVAL=3", date(2024, 1, 1), []time.Time{date(2024, 1, 4), date(2024, 1, 7)}},
+		{"FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", date(2024, 1, 4), []time.Time{date(2024, 1, 5), date(2024, 1, 8)}},
+		{"FREQ=WEEKLY", date(2024, 1, 1), []time.Time{date(2024, 1, 8), date(2024, 1, 15)}},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", date(2024, 1, 1), []time.Time{date(2024, 1, 4), date(2024, 1, 15), date(2024, 1, 18)}},
+		{"FREQ=MONTHLY", date(2024, 1, 31), []time.Time{date(2024, 3, 31), date(2024, 5, 31)}},
+		{"FREQ=MONTHLY;BYDAY=1MO", date(2024, 1, 1), []time.Time{date(2024, 2, 5), date(2024, 3, 4)}},
+		{"FREQ=MONTHLY;BYDAY=-1FR", date(2024, 1, 26), []time.Time{date(2024, 2, 23), date(2024, 3, 29)}},
+		{"FREQ=MONTHLY;INTERVAL=2;BYDAY=2TU,4TU", date(2024, 1, 9), []time.Time{date(2024, 1, 23), date(2024, 3, 12)}},
+		{"FREQ=YEARLY", date(2024, 2, 29), []time.Time{date(2028, 2, 29)}},
+		{"FREQ=DAILY;UNTIL=20240102", date(2024, 1, 1), []time.Time{date(2024, 1, 2)}},
+	}
+
+	for _, testCase := range testCases {
+		r := mustParseRecurrence(t, testCase.rule)
+		due := testCase.first
+		for _, expected := range testCase.expected {
+			next, ok := r.Next(due)
+			if !ok || !next.Equal(expected) {
+				t.Errorf("%q after %s: expected %s found %s (%v)", testCase.rule, due.Format(rruleDate), expected.Format(rruleDate), next.Format(rruleDate), ok)
+				break
+			}
+			due = next
+		}
+	}
+
+	// occurrences keep the time of day and time zone, across daylight saving time
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, ok := mustParseRecurrence(t, "FREQ=WEEKLY").Next(time.Date(2024, time.March, 4, 9, 30, 0, 0, newYork))
+	if expected := time.Date(2024, time.March, 11, 9, 30, 0, 0, newYork); !ok || !next.Equal(expected) || next.Location() != newYork {
+		t.Errorf("expected %v found %v", expected, next)
+	}
+
+	if next, ok := mustParseRecurrence(t, "FREQ=DAILY;UNTIL=20240102").Next(date(2024, 1, 2)); ok {
+		t.Errorf("expected the rule to end, found %s", next)
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	r := mustParseRecurrence(t, "FREQ=WEEKLY;COUNT=2")
+	task := NewTask("take out the bins", "")
+	task.Priority = High
+	task.AddDueDate(date(2024, 1, 1))
+	task.Recurrence = &r
+	task.Complete()
+
+	next, ok := task.NextOccurrence()
+	if !ok {
+		t.Fatal("expected a second occurrence")
+	}
+	if next.ID == task.ID || next.Name != task.Name || next.Priority != task.Priority {
+		t.Errorf("unexpected occurrence %+v", next)
+	}
+	if next.Completed != nil {
+		t.Error("the next occurrence should be open")
+	}
+	if !next.DueDate.Equal(date(2024, 1, 8)) {
+		t.Errorf("expected %s found %s", date(2024, 1, 8), next.DueDate)
+	}
+	if next.Recurrence.Count != 1 || task.Recurrence.Count != 2 {
+		t.Errorf("expected counts %d and %d found %d and %d", 2, 1, task.Recurrence.Count, next.Recurrence.Count)
+	}
+
+	if _, ok := next.NextOccurrence(); ok {
+		t.Error("expected the last occurrence to end the series")
+	}
+
+	oneOff := NewTask("one-off", "")
+	oneOff.AddDueDate(date(2024, 1, 1))
+	if _, ok := oneOff.NextOccurrence(); ok {
+		t.Error("a task without a recurrence should not repeat")
+	}
+}
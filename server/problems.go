@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/peschkaj/togo/api"
+	"github.com/peschkaj/togo/store"
+	"log"
+	"net/http"
+)
+
+// Problem types reported in the type member of RFC 7807 problem details. They
+// are relative references, resolved against the server's base URL.
+const (
+	problemNotFound         = "/problems/not-found"
+	problemConflict         = "/problems/conflict"
+	problemInvalid          = "/problems/invalid"
+	problemTimeout          = "/problems/timeout"
+	problemCanceled         = "/problems/canceled"
+	problemBadRequest       = "/problems/bad-request"
+	problemMethodNotAllowed = "/problems/method-not-allowed"
+	problemInternal         = "/problems/internal"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	requestIDHeader    = "X-Request-Id"
+
+	// statusClientClosedRequest is the non-standard status nginx popularized
+	// for a request the client gave up on before it was answered.
+	statusClientClosedRequest = 499
+)
+
+type requestIDKey struct{}
+
+// withRequestID gives every request an ID, returned in the X-Request-Id header
+// and used as the instance of any problem reported for the request.
+func withRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := uuid.NewString()
+		w.Header().Set(requestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+func requestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// responseError reports an error returned by a handler. Store errors describe
+// a problem with the request and a canceled context means the client went
+// away, so neither is logged; anything else is logged and reported as an
+// internal error without exposing its details.
+func responseError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		writeProblem(w, r, newProblem(problemNotFound, http.StatusNotFound, err.Error()))
+	case errors.Is(err, store.ErrConflict):
+		writeProblem(w, r, newProblem(problemConflict, http.StatusConflict, err.Error()))
+	case errors.Is(err, store.ErrInvalid):
+		writeProblem(w, r, newProblem(problemInvalid, http.StatusBadRequest, err.Error()))
+	case errors.Is(err, store.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		writeProblem(w, r, newProblem(problemTimeout, http.StatusGatewayTimeout, "the request took too long to complete"))
+	case errors.Is(err, context.Canceled):
+		writeProblem(w, r, newProblem(problemCanceled, statusClientClosedRequest, "the request was canceled"))
+	default:
+		log.Printf("togo-server: %s %s [%s]: %v", r.Method, r.URL.Path, requestID(r.Context()), err)
+		writeProblem(w, r, newProblem(problemInternal, http.StatusInternalServerError, "the server was unable to complete the request"))
+	}
+}
+
+// requestError reports a request that could not be decoded: malformed or
+// missing parameters and unreadable bodies. Parameter errors name the
+// offending parameter in invalid-params.
+func requestError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := newProblem(problemBadRequest, http.StatusBadRequest, err.Error())
+	if name, ok := paramName(err); ok {
+		problem.Set("invalid-params", []map[string]string{{"name": name, "reason": err.Error()}})
+	}
+	writeProblem(w, r, problem)
+}
+
+func paramName(err error) (string, bool) {
+	var (
+		invalidFormat *api.InvalidParamFormatError
+		required      *api.RequiredParamError
+		requiredHdr   *api.RequiredHeaderError
+		unmarshalling *api.UnmarshallingParamError
+		tooMany       *api.TooManyValuesForParamError
+	)
+	switch {
+	case errors.As(err, &invalidFormat):
+		return invalidFormat.ParamName, true
+	case errors.As(err, &required):
+		return required.ParamName, true
+	case errors.As(err, &requiredHdr):
+		return requiredHdr.ParamName, true
+	case errors.As(err, &unmarshalling):
+		return unmarshalling.ParamName, true
+	case errors.As(err, &tooMany):
+		return tooMany.ParamName, true
+	}
+	return "", false
+}
+
+func notFound(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, newProblem(problemNotFound, http.StatusNotFound, "no resource at "+r.URL.Path))
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, newProblem(problemMethodNotAllowed, http.StatusMethodNotAllowed, r.Method+" is not supported by "+r.URL.Path))
+}
+
+func newProblem(problemType string, status int, detail string) api.ProblemDetails {
+	title := http.StatusText(status)
+	return api.ProblemDetails{Type: &problemType, Title: &title, Status: &status, Detail: &detail}
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, problem api.ProblemDetails) {
+	if id := requestID(r.Context()); id != "" {
+		instance := "urn:uuid:" + id
+		problem.Instance = &instance
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(*problem.Status)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		log.Printf("togo-server: unable to write problem details: %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/peschkaj/togo/api"
+	"github.com/peschkaj/togo/store"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func decodeProblem(t *testing.T, resp *http.Response) api.ProblemDetails {
+	t.Helper()
+	defer resp.Body.Close()
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != problemContentType {
+		t.Fatalf("expected content type %q found %q", problemContentType, contentType)
+	}
+
+	var problem api.ProblemDetails
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+		t.Fatal(err)
+	}
+	return problem
+}
+
+func send(t *testing.T, ts *httptest.Server, method, path, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestErrorsAreReportedAsProblems(t *testing.T) {
+	ts := newTestServer(t)
+	expectStatus(t, do(t, ts, http.MethodPut, "/projects/taken", api.ProjectInput{}, nil), http.StatusCreated)
+
+	testCases := []struct {
+		method, path, body string
+		status             int
+		problemType        string
+	}{
+		{method: http.MethodGet, path: "/tasks/" + uuid.New().String(), status: http.StatusNotFound, problemType: problemNotFound},
+		{method: http.MethodGet, path: "/projects/missing", status: http.StatusNotFound, problemType: problemNotFound},
+		{method: http.MethodPost, path: "/tasks", body: `{"name": ""}`, status: http.StatusBadRequest, problemType: problemInvalid},
+		{method: http.MethodPut, path: "/projects/" + strings.Repeat("x", store.MaxNameLength+1), body: `{}`, status: http.StatusBadRequest, problemType: problemInvalid},
+		{method: http.MethodGet, path: "/tasks/not-a-uuid", status: http.StatusBadRequest, problemType: problemBadRequest},
+		{method: http.MethodPost, path: "/tasks", body: `{"name": `, status: http.StatusBadRequest, problemType: problemBadRequest},
+		{method: http.MethodGet, path: "/nowhere", status: http.StatusNotFound, problemType: problemNotFound},
+		{method: http.MethodPatch, path: "/tasks", status: http.StatusMethodNotAllowed, problemType: problemMethodNotAllowed},
+	}
+
+	for _, testCase := range testCases {
+		resp := send(t, ts, testCase.method, testCase.path, testCase.body)
+		if resp.StatusCode != testCase.status {
+			t.Errorf("%s %s: expected status %d found %d", testCase.method, testCase.path, testCase.status, resp.StatusCode)
+		}
+
+		problem := decodeProblem(t, resp)
+		if problem.Type == nil || *problem.Type != testCase.problemType {
+			t.Errorf("%s %s: expected type %q found %v", testCase.method, testCase.path, testCase.problemType, problem.Type)
+		}
+		if problem.Status == nil || *problem.Status != testCase.status {
+			t.Errorf("%s %s: expected problem status %d found %v", testCase.method, testCase.path, testCase.status, problem.Status)
+		}
+
+		expectedInstance := "urn:uuid:" + resp.Header.Get(requestIDHeader)
+		if problem.Instance == nil || *problem.Instance != expectedInstance {
+			t.Errorf("%s %s: expected instance %q found %v", testCase.method, testCase.path, expectedInstance, problem.Instance)
+		}
+	}
+}
+
+func TestInvalidParamsAreNamed(t *testing.T) {
+	ts := newTestServer(t)
+
+	problem := decodeProblem(t, send(t, ts, http.MethodDelete, "/tasks/not-a-uuid", ""))
+
+	params, found := problem.Get("invalid-params")
+	if !found {
+		t.Fatal("problem does not list invalid parameters")
+	}
+	list, ok := params.([]interface{})
+	if !ok || len(list) != 1 {
+		t.Fatalf("expected a single invalid parameter, found %v", params)
+	}
+	if name := list[0].(map[string]interface{})["name"]; name != "id" {
+		t.Errorf("expected parameter %q found %v", "id", name)
+	}
+}
+
+func TestResponseErrorsAreMapped(t *testing.T) {
+	testCases := []struct {
+		err         error
+		status      int
+		problemType string
+	}{
+		{err: store.NotFound("FindTask", nil), status: http.StatusNotFound, problemType: problemNotFound},
+		{err: store.Conflict("AddOrUpdateProject", nil), status: http.StatusConflict, problemType: problemConflict},
+		{err: store.Invalid("AddOrUpdateTask", nil), status: http.StatusBadRequest, problemType: problemInvalid},
+		{err: store.Timeout("All", nil), status: http.StatusGatewayTimeout, problemType: problemTimeout},
+		{err: fmt.Errorf("querying: %w", context.DeadlineExceeded), status: http.StatusGatewayTimeout, problemType: problemTimeout},
+		{err: fmt.Errorf("querying: %w", context.Canceled), status: statusClientClosedRequest, problemType: problemCanceled},
+		{err: errors.New("connection reset"), status: http.StatusInternalServerError, problemType: problemInternal},
+	}
+
+	for _, testCase := range testCases {
+		w := httptest.NewRecorder()
+		responseError(w, httptest.NewRequest(http.MethodGet, "/tasks", nil), testCase.err)
+
+		problem := decodeProblem(t, w.Result())
+		if w.Code != testCase.status {
+			t.Errorf("%v: expected status %d found %d", testCase.err, testCase.status, w.Code)
+		}
+		if problem.Type == nil || *problem.Type != testCase.problemType {
+			t.Errorf("%v: expected type %q found %v", testCase.err, testCase.problemType, problem.Type)
+		}
+	}
+}
+
+func TestInternalErrorsAreNotExposed(t *testing.T) {
+	w := httptest.NewRecorder()
+	responseError(w, httptest.NewRequest(http.MethodGet, "/tasks", nil), errors.New("password authentication failed"))
+
+	problem := decodeProblem(t, w.Result())
+	if problem.Detail != nil && strings.Contains(*problem.Detail, "password") {
+		t.Errorf("internal error leaked into the response: %q", *problem.Detail)
+	}
+}
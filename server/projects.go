@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/peschkaj/togo"
+	"github.com/peschkaj/togo/api"
+	"github.com/peschkaj/togo/store"
+)
+
+// ListProjects returns every project, or the project with the requested ID.
+func (srv *Server) ListProjects(ctx context.Context, request api.ListProjectsRequestObject) (api.ListProjectsResponseObject, error) {
+	var projects []togo.Project
+	if request.Params.Id != nil {
+		project, err := srv.store.FindProject(ctx, *request.Params.Id)
+		switch {
+		case err == nil:
+			projects = append(projects, project)
+		case !errors.Is(err, store.ErrNotFound):
+			return nil, err
+		}
+	} else {
+		var err error
+		if projects, err = srv.store.AllProjects(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	response := make(api.ListProjects200JSONResponse, 0, len(projects))
+	for _, p := range projects {
+		response = append(response, toAPIProject(p))
+	}
+	return response, nil
+}
+
+func (srv *Server) GetProject(ctx context.Context, request api.GetProjectRequestObject) (api.GetProjectResponseObject, error) {
+	project, err := srv.store.FindProjectByName(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.GetProject200JSONResponse(toAPIProject(project)), nil
+}
+
+// PutProject creates the named project or, when it already exists, replaces
+// its description. A request carrying an ID creates or renames the project
+// with that ID instead.
+func (srv *Server) PutProject(ctx context.Context, request api.PutProjectRequestObject) (api.PutProjectResponseObject, error) {
+	var (
+		project togo.Project
+		err     error
+	)
+	if request.Body.Id != nil {
+		project, err = srv.store.FindProject(ctx, *request.Body.Id)
+	} else {
+		project, err = srv.store.FindProjectByName(ctx, request.Name)
+	}
+
+	created := errors.Is(err, store.ErrNotFound)
+	if created {
+		project = togo.NewProject(request.Name, "")
+		if request.Body.Id != nil {
+			project.ID = *request.Body.Id
+		}
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	project.Name = request.Name
+	project.Description = ""
+	if request.Body.Description != nil {
+		project.Description = *request.Body.Description
+	}
+	if request.Body.Created != nil {
+		project.Created = *request.Body.Created
+	}
+
+	if err := srv.store.AddOrUpdateProject(ctx, project); err != nil {
+		return nil, err
+	}
+
+	if created {
+		return api.PutProject201JSONResponse(toAPIProject(project)), nil
+	}
+	return api.PutProject200JSONResponse(toAPIProject(project)), nil
+}
+
+func (srv *Server) DeleteProject(ctx context.Context, request api.DeleteProjectRequestObject) (api.DeleteProjectResponseObject, error) {
+	project, err := srv.store.FindProjectByName(ctx, request.Name)
+	if err == nil {
+		err = srv.store.RemoveProject(ctx, project.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return api.DeleteProject204Response{}, nil
+}
+
+func toAPIProject(p togo.Project) api.Project {
+	return api.Project{Id: p.ID, Name: p.Name, Description: &p.Description, Created: p.Created}
+}
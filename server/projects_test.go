@@ -0,0 +1,107 @@
+package server
+
+import (
+	"github.com/google/uuid"
+	"github.com/jaswdr/faker"
+	"github.com/peschkaj/togo/api"
+	"net/http"
+	"testing"
+)
+
+func TestProjectLifecycle(t *testing.T) {
+	ts := newTestServer(t)
+	f := faker.New()
+	name := f.UUID().V4()
+	path := "/projects/" + name
+
+	first := "first"
+	var created api.Project
+	expectStatus(t, do(t, ts, http.MethodPut, path, api.ProjectInput{Description: &first}, &created), http.StatusCreated)
+	if created.Id == uuid.Nil || created.Name != name {
+		t.Fatalf("unexpected project %+v", created)
+	}
+
+	second := "second"
+	var updated api.Project
+	expectStatus(t, do(t, ts, http.MethodPut, path, api.ProjectInput{Description: &second}, &updated), http.StatusOK)
+	if updated.Id != created.Id {
+		t.Error("updating a project changed its ID")
+	}
+
+	var found api.Project
+	expectStatus(t, do(t, ts, http.MethodGet, path, nil, &found), http.StatusOK)
+	if found.Name != name || found.Description == nil || *found.Description != second {
+		t.Errorf("expected the updated project, found %+v", found)
+	}
+
+	expectStatus(t, do(t, ts, http.MethodDelete, path, nil, nil), http.StatusNoContent)
+	expectStatus(t, do(t, ts, http.MethodGet, path, nil, nil), http.StatusNotFound)
+	expectStatus(t, do(t, ts, http.MethodDelete, path, nil, nil), http.StatusNotFound)
+}
+
+func TestProjectsCanBeRenamedByID(t *testing.T) {
+	ts := newTestServer(t)
+
+	var project api.Project
+	expectStatus(t, do(t, ts, http.MethodPut, "/projects/before", api.ProjectInput{}, &project), http.StatusCreated)
+
+	var renamed api.Project
+	expectStatus(t, do(t, ts, http.MethodPut, "/projects/after", api.ProjectInput{Id: &project.Id}, &renamed), http.StatusOK)
+	if renamed.Id != project.Id || renamed.Name != "after" {
+		t.Errorf("project was not renamed: %+v", renamed)
+	}
+	expectStatus(t, do(t, ts, http.MethodGet, "/projects/before", nil, nil), http.StatusNotFound)
+
+	var found []api.Project
+	expectStatus(t, do(t, ts, http.MethodGet, "/projects?id="+project.Id.String(), nil, &found), http.StatusOK)
+	if len(found) != 1 || found[0].Name != "after" {
+		t.Errorf("expected the renamed project, found %+v", found)
+	}
+
+	expectStatus(t, do(t, ts, http.MethodGet, "/projects?id="+uuid.New().String(), nil, &found), http.StatusOK)
+	if len(found) != 0 {
+		t.Errorf("expected no projects, found %+v", found)
+	}
+
+	var other api.Project
+	expectStatus(t, do(t, ts, http.MethodPut, "/projects/other", api.ProjectInput{}, &other), http.StatusCreated)
+	expectStatus(t, do(t, ts, http.MethodPut, "/projects/after", api.ProjectInput{Id: &other.Id}, nil), http.StatusConflict)
+}
+
+func TestProjectsAreListedByName(t *testing.T) {
+	ts := newTestServer(t)
+
+	for _, name := range []string{"b", "c", "a"} {
+		expectStatus(t, do(t, ts, http.MethodPut, "/projects/"+name, api.ProjectInput{}, nil), http.StatusCreated)
+	}
+
+	var projects []api.Project
+	expectStatus(t, do(t, ts, http.MethodGet, "/projects", nil, &projects), http.StatusOK)
+	if len(projects) != 3 {
+		t.Fatalf("expected %d projects found %d", 3, len(projects))
+	}
+	for i, name := range []string{"a", "b", "c"} {
+		if projects[i].Name != name {
+			t.Errorf("expected %q at %d found %q", name, i, projects[i].Name)
+		}
+	}
+}
+
+func TestDeletingAProjectKeepsItsTasks(t *testing.T) {
+	ts := newTestServer(t)
+
+	var project api.Project
+	expectStatus(t, do(t, ts, http.MethodPut, "/projects/work", api.ProjectInput{}, &project), http.StatusCreated)
+
+	var task api.Task
+	input := api.TaskInput{Name: "report", ProjectId: &project.Id}
+	expectStatus(t, do(t, ts, http.MethodPost, "/tasks", input, &task), http.StatusCreated)
+
+	expectStatus(t, do(t, ts, http.MethodDelete, "/projects/work", nil, nil), http.StatusNoContent)
+
+	var found api.Task
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/"+task.Id.String(), nil, &found), http.StatusOK)
+	if found.ProjectId != nil {
+		t.Error("task still belongs to the deleted project")
+	}
+}
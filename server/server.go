@@ -0,0 +1,39 @@
+// Package server implements the togo HTTP API described in api/swagger.yaml
+// on top of any store.Store.
+package server
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/peschkaj/togo/api"
+	"github.com/peschkaj/togo/store"
+	"net/http"
+)
+
+// Server handles API requests against a backing store.
+type Server struct {
+	store store.Store
+}
+
+var _ api.StrictServerInterface = (*Server)(nil)
+
+func NewServer(s store.Store) *Server {
+	return &Server{store: s}
+}
+
+// Handler returns an http.Handler that routes every API operation to srv.
+// Errors are reported as RFC 7807 problem details.
+func (srv *Server) Handler() http.Handler {
+	r := chi.NewRouter()
+	r.Use(withRequestID)
+	r.NotFound(notFound)
+	r.MethodNotAllowed(methodNotAllowed)
+
+	strict := api.NewStrictHandlerWithOptions(srv, nil, api.StrictHTTPServerOptions{
+		RequestErrorHandlerFunc:  requestError,
+		ResponseErrorHandlerFunc: responseError,
+	})
+	return api.HandlerWithOptions(strict, api.ChiServerOptions{
+		BaseRouter:       r,
+		ErrorHandlerFunc: requestError,
+	})
+}
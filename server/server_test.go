@@ -0,0 +1,56 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/peschkaj/togo/store/memory"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(NewServer(memory.NewMemoryStore()).Handler())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// do sends body, if any, as JSON and decodes a JSON response into out, if
+// given.
+func do(t *testing.T, ts *httptest.Server, method, path string, body, out interface{}) *http.Response {
+	t.Helper()
+
+	var reader bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reader).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req, err := http.NewRequest(method, ts.URL+path, &reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode < http.StatusMultipleChoices {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return resp
+}
+
+func expectStatus(t *testing.T, resp *http.Response, status int) {
+	t.Helper()
+	if resp.StatusCode != status {
+		t.Fatalf("%s %s: expected status %d found %d", resp.Request.Method, resp.Request.URL.Path, status, resp.StatusCode)
+	}
+}
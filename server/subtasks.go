@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"github.com/peschkaj/togo"
+	"github.com/peschkaj/togo/api"
+)
+
+func (srv *Server) ListSubtasks(ctx context.Context, request api.ListSubtasksRequestObject) (api.ListSubtasksResponseObject, error) {
+	subtasks, err := srv.store.Subtasks(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	return api.ListSubtasks200JSONResponse(toAPITasks(subtasks)), nil
+}
+
+// CreateSubtask looks the parent up first so that an unknown parent is
+// reported as a missing resource rather than an invalid task.
+func (srv *Server) CreateSubtask(ctx context.Context, request api.CreateSubtaskRequestObject) (api.CreateSubtaskResponseObject, error) {
+	const op = "CreateSubtask"
+	parent, err := srv.store.FindTask(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	task := togo.NewTask(request.Body.Name, "")
+	if err := applyTaskInput(op, &task, *request.Body); err != nil {
+		return nil, err
+	}
+	if err := applyStatus(op, &task, *request.Body); err != nil {
+		return nil, err
+	}
+	task.AddToParent(parent)
+	if err := srv.store.AddOrUpdateTask(ctx, task); err != nil {
+		return nil, err
+	}
+
+	return api.CreateSubtask201JSONResponse(toAPITask(task)), nil
+}
+
+func (srv *Server) MoveTask(ctx context.Context, request api.MoveTaskRequestObject) (api.MoveTaskResponseObject, error) {
+	if err := srv.store.MoveTask(ctx, request.Id, request.Body.ParentId); err != nil {
+		return nil, err
+	}
+
+	task, err := srv.store.FindTask(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	return api.MoveTask200JSONResponse(toAPITask(task)), nil
+}
+
+func (srv *Server) GetTaskProgress(ctx context.Context, request api.GetTaskProgressRequestObject) (api.GetTaskProgressResponseObject, error) {
+	subtasks, err := srv.store.Subtasks(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := togo.RollUp(subtasks)
+	return api.GetTaskProgress200JSONResponse{Completed: progress.Completed, Total: progress.Total}, nil
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"github.com/google/uuid"
+	"github.com/peschkaj/togo/api"
+	"net/http"
+	"testing"
+)
+
+func TestSubtaskLifecycle(t *testing.T) {
+	ts := newTestServer(t)
+
+	var parent, first, second api.Task
+	expectStatus(t, do(t, ts, http.MethodPost, "/tasks", api.TaskInput{Name: "move house"}, &parent), http.StatusCreated)
+	subtasksPath := "/tasks/" + parent.Id.String() + "/subtasks"
+	expectStatus(t, do(t, ts, http.MethodPost, subtasksPath, api.TaskInput{Name: "pack"}, &first), http.StatusCreated)
+	expectStatus(t, do(t, ts, http.MethodPost, subtasksPath, api.TaskInput{Name: "book a van"}, &second), http.StatusCreated)
+	if first.ParentId == nil || *first.ParentId != parent.Id {
+		t.Fatalf("expected parent %v found %v", parent.Id, first.ParentId)
+	}
+
+	var subtasks []api.Task
+	expectStatus(t, do(t, ts, http.MethodGet, subtasksPath, nil, &subtasks), http.StatusOK)
+	if len(subtasks) != 2 {
+		t.Fatalf("expected %d subtasks found %d", 2, len(subtasks))
+	}
+
+	expectStatus(t, do(t, ts, http.MethodPost, "/tasks/"+first.Id.String()+"/complete", nil, nil), http.StatusOK)
+	var progress api.TaskProgress
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/"+parent.Id.String()+"/progress", nil, &progress), http.StatusOK)
+	if progress.Completed != 1 || progress.Total != 2 {
+		t.Errorf("expected 1 of 2 subtasks completed found %+v", progress)
+	}
+
+	var moved api.Task
+	expectStatus(t, do(t, ts, http.MethodPut, "/tasks/"+second.Id.String()+"/parent", api.TaskParent{}, &moved), http.StatusOK)
+	if moved.ParentId != nil {
+		t.Errorf("expected a top-level task found parent %v", *moved.ParentId)
+	}
+	expectStatus(t, do(t, ts, http.MethodPut, "/tasks/"+parent.Id.String()+"/parent", api.TaskParent{ParentId: &first.Id}, nil), http.StatusBadRequest)
+
+	expectStatus(t, do(t, ts, http.MethodDelete, "/tasks/"+parent.Id.String()+"?subtasks=restrict", nil, nil), http.StatusConflict)
+	expectStatus(t, do(t, ts, http.MethodDelete, "/tasks/"+parent.Id.String()+"?subtasks=remove", nil, nil), http.StatusNoContent)
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/"+first.Id.String(), nil, nil), http.StatusNotFound)
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/"+second.Id.String(), nil, nil), http.StatusOK)
+}
+
+func TestSubtasksOfMissingTasks(t *testing.T) {
+	ts := newTestServer(t)
+	missing := "/tasks/" + uuid.NewString()
+
+	expectStatus(t, do(t, ts, http.MethodGet, missing+"/subtasks", nil, nil), http.StatusNotFound)
+	expectStatus(t, do(t, ts, http.MethodPost, missing+"/subtasks", api.TaskInput{Name: "orphan"}, nil), http.StatusNotFound)
+	expectStatus(t, do(t, ts, http.MethodGet, missing+"/progress", nil, nil), http.StatusNotFound)
+	expectStatus(t, do(t, ts, http.MethodPut, missing+"/parent", api.TaskParent{}, nil), http.StatusNotFound)
+	expectStatus(t, do(t, ts, http.MethodDelete, missing+"?subtasks=everything", nil, nil), http.StatusBadRequest)
+}
@@ -0,0 +1,597 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	"github.com/peschkaj/togo"
+	"github.com/peschkaj/togo/api"
+	"github.com/peschkaj/togo/store"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+func (srv *Server) ListTasks(ctx context.Context, request api.ListTasksRequestObject) (api.ListTasksResponseObject, error) {
+	page, err := srv.findTasks(ctx, request.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	response := taskPageResponse{Body: toAPITasks(page.Tasks)}
+	if page.Next != "" {
+		next := url.Values{"cursor": {page.Next}}
+		if request.Params.Limit != nil {
+			next.Set("limit", strconv.Itoa(*request.Params.Limit))
+		}
+		response.Headers.XNextCursor = page.Next
+		response.Headers.Link = fmt.Sprintf(`</tasks?%s>; rel="next"`, next.Encode())
+	}
+	return response, nil
+}
+
+// taskPageResponse only sends the paging headers when another page follows;
+// the generated response always sets them.
+type taskPageResponse api.ListTasks200JSONResponse
+
+func (response taskPageResponse) VisitListTasksResponse(w http.ResponseWriter) error {
+	if response.Headers.XNextCursor != "" {
+		w.Header().Set("Link", response.Headers.Link)
+		w.Header().Set("X-Next-Cursor", response.Headers.XNextCursor)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// findTasks runs the store query selected by the list filters, or lists a
+// page of every task when there are none. Filtered results aren't paged.
+func (srv *Server) findTasks(ctx context.Context, params api.ListTasksParams) (store.TaskPage, error) {
+	const op = "ListTasks"
+
+	undated := params.Undated != nil && *params.Undated
+	filters := 0
+	for _, set := range []bool{
+		params.Name != nil,
+		params.DueOn != nil,
+		undated,
+		params.DueFrom != nil || params.DueTo != nil,
+		params.ProjectId != nil,
+	} {
+		if set {
+			filters++
+		}
+	}
+	if filters > 1 {
+		return store.TaskPage{}, store.Invalid(op, errors.New("name, dueOn, undated, dueFrom and dueTo, and projectId can't be combined"))
+	}
+	if filters > 0 && (params.Limit != nil || params.Cursor != nil) {
+		return store.TaskPage{}, store.Invalid(op, errors.New("filtered tasks can't be paged"))
+	}
+
+	loc, err := loadZone(op, params.Tz)
+	if err != nil {
+		return store.TaskPage{}, err
+	}
+
+	var tasks []togo.Task
+	switch {
+	case params.Name != nil:
+		tasks, err = srv.store.FindTasksByName(ctx, *params.Name)
+	case params.DueOn != nil:
+		tasks, err = srv.store.FindByDueDate(ctx, &params.DueOn.Time, loc)
+	case undated:
+		tasks, err = srv.store.FindByDueDate(ctx, nil, loc)
+	case params.DueFrom != nil || params.DueTo != nil:
+		if params.DueFrom == nil || params.DueTo == nil {
+			return store.TaskPage{}, store.Invalid(op, errors.New("dueFrom and dueTo must be used together"))
+		}
+		tasks, err = srv.store.FindDueBetween(ctx, params.DueFrom.Time, params.DueTo.Time, loc)
+	case params.ProjectId != nil:
+		tasks, err = srv.store.TasksInProject(ctx, *params.ProjectId)
+	default:
+		var page store.Page
+		if params.Limit != nil {
+			if *params.Limit < 1 {
+				return store.TaskPage{}, store.Invalid(op, errors.New("limit must be at least 1"))
+			}
+			page.Limit = *params.Limit
+		}
+		if params.Cursor != nil {
+			page.Cursor = *params.Cursor
+		}
+		return srv.store.ListTasks(ctx, page)
+	}
+	return store.TaskPage{Tasks: tasks}, err
+}
+
+func (srv *Server) SearchTasks(ctx context.Context, request api.SearchTasksRequestObject) (api.SearchTasksResponseObject, error) {
+	q, err := toQuery(request.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := srv.store.QueryTasks(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return api.SearchTasks200JSONResponse(toAPITasks(tasks)), nil
+}
+
+func toQuery(params api.SearchTasksParams) (store.Query, error) {
+	const op = "SearchTasks"
+
+	loc, err := loadZone(op, params.Tz)
+	if err != nil {
+		return store.Query{}, err
+	}
+
+	q := store.Query{
+		Completed:   params.Completed,
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+		ProjectID:   params.ProjectId,
+		Location:    loc,
+	}
+	if params.Status != nil {
+		for _, status := range *params.Status {
+			s, err := fromAPIStatus(op, status)
+			if err != nil {
+				return store.Query{}, err
+			}
+			q.Statuses = append(q.Statuses, s)
+		}
+	}
+	if params.MinPriority != nil {
+		p, err := fromAPIPriority(op, *params.MinPriority)
+		if err != nil {
+			return store.Query{}, err
+		}
+		q.MinPriority = &p
+	}
+	if params.MaxPriority != nil {
+		p, err := fromAPIPriority(op, *params.MaxPriority)
+		if err != nil {
+			return store.Query{}, err
+		}
+		q.MaxPriority = &p
+	}
+	if params.DueFrom != nil {
+		q.DueFrom = &params.DueFrom.Time
+	}
+	if params.DueTo != nil {
+		q.DueTo = &params.DueTo.Time
+	}
+	if params.Q != nil {
+		q.Text = *params.Q
+	}
+	if params.Sort != nil {
+		for _, s := range *params.Sort {
+			key, err := store.ParseSortKey(op, s)
+			if err != nil {
+				return store.Query{}, err
+			}
+			q.Sort = append(q.Sort, key)
+		}
+	}
+	return q, nil
+}
+
+func (srv *Server) ListOverdueTasks(ctx context.Context, request api.ListOverdueTasksRequestObject) (api.ListOverdueTasksResponseObject, error) {
+	loc, err := loadZone("ListOverdueTasks", request.Params.Tz)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := srv.store.OverdueTasks(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+	return api.ListOverdueTasks200JSONResponse(toAPITasks(tasks)), nil
+}
+
+func (srv *Server) ListUpcomingTasks(ctx context.Context, request api.ListUpcomingTasksRequestObject) (api.ListUpcomingTasksResponseObject, error) {
+	loc, err := loadZone("ListUpcomingTasks", request.Params.Tz)
+	if err != nil {
+		return nil, err
+	}
+
+	window := store.UpcomingWindow{Days: 7, Location: loc}
+	if request.Params.Days != nil {
+		if *request.Params.Days < 0 {
+			return nil, store.Invalid("ListUpcomingTasks", errors.New("days can't be negative"))
+		}
+		window.Days = *request.Params.Days
+	}
+	if request.Params.IncludeCompleted != nil {
+		window.IncludeCompleted = *request.Params.IncludeCompleted
+	}
+
+	tasks, err := srv.store.Upcoming(ctx, window)
+	if err != nil {
+		return nil, err
+	}
+	return api.ListUpcomingTasks200JSONResponse(toAPITasks(tasks)), nil
+}
+
+// loadZone loads the time zone named by a tz parameter, nil when there is none.
+func loadZone(op string, tz *api.TimeZone) (*time.Location, error) {
+	if tz == nil {
+		return nil, nil
+	}
+	loc, err := togo.LoadZone(*tz)
+	if err != nil {
+		return nil, store.Invalid(op, err)
+	}
+	return loc, nil
+}
+
+func (srv *Server) CountTasks(ctx context.Context, _ api.CountTasksRequestObject) (api.CountTasksResponseObject, error) {
+	count, err := srv.store.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.CountTasks200JSONResponse{Count: count}, nil
+}
+
+func (srv *Server) CreateTask(ctx context.Context, request api.CreateTaskRequestObject) (api.CreateTaskResponseObject, error) {
+	const op = "CreateTask"
+	task := togo.NewTask(request.Body.Name, "")
+	err := applyTaskInput(op, &task, *request.Body)
+	if err == nil {
+		err = applyStatus(op, &task, *request.Body)
+	}
+	if err == nil {
+		err = srv.store.AddOrUpdateTask(ctx, task)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return api.CreateTask201JSONResponse(toAPITask(task)), nil
+}
+
+func (srv *Server) GetTask(ctx context.Context, request api.GetTaskRequestObject) (api.GetTaskResponseObject, error) {
+	task, err := srv.store.FindTask(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.GetTask200JSONResponse(toAPITask(task)), nil
+}
+
+// PutTask creates a task with the requested ID or replaces the editable fields
+// of an existing one. Fields left out of the request are cleared, but an
+// existing task keeps its status, completion time and transitions: those only
+// change through CompleteTask, TransitionTask and ReopenTask.
+func (srv *Server) PutTask(ctx context.Context, request api.PutTaskRequestObject) (api.PutTaskResponseObject, error) {
+	const op = "PutTask"
+	task, err := srv.store.FindTask(ctx, request.Id)
+	created := errors.Is(err, store.ErrNotFound)
+	if created {
+		task = togo.NewTask(request.Body.Name, "")
+		task.ID = request.Id
+		err = nil
+	}
+	if err == nil {
+		err = applyTaskInput(op, &task, *request.Body)
+	}
+	if err == nil && created {
+		err = applyStatus(op, &task, *request.Body)
+	}
+	if err == nil && !created {
+		err = keepStatus(op, task, *request.Body)
+	}
+	if err == nil {
+		err = srv.store.AddOrUpdateTask(ctx, task)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		return api.PutTask201JSONResponse(toAPITask(task)), nil
+	}
+	return api.PutTask200JSONResponse(toAPITask(task)), nil
+}
+
+func (srv *Server) DeleteTask(ctx context.Context, request api.DeleteTaskRequestObject) (api.DeleteTaskResponseObject, error) {
+	rule := store.OrphanSubtasks
+	if request.Params.Subtasks != nil {
+		switch *request.Params.Subtasks {
+		case api.Orphan:
+			rule = store.OrphanSubtasks
+		case api.Remove:
+			rule = store.RemoveSubtasks
+		case api.Restrict:
+			rule = store.RestrictSubtasks
+		default:
+			return nil, store.Invalid("DeleteTask", fmt.Errorf("unknown subtasks rule %q", *request.Params.Subtasks))
+		}
+	}
+
+	if err := srv.store.RemoveTaskWith(ctx, request.Id, rule); err != nil {
+		return nil, err
+	}
+
+	return api.DeleteTask204Response{}, nil
+}
+
+// CompleteTask marks a task as completed. Completing a task that is already
+// completed keeps the original completion time. The next occurrence of a
+// recurring task is linked from the response.
+func (srv *Server) CompleteTask(ctx context.Context, request api.CompleteTaskRequestObject) (api.CompleteTaskResponseObject, error) {
+	completion, err := srv.store.CompleteTask(withActor(ctx, request.Params.XActor), request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return completionResponse{completion}, nil
+}
+
+// TransitionTask moves a task to another status. Like CompleteTask, the next
+// occurrence created by completing a recurring task is linked from the
+// response.
+func (srv *Server) TransitionTask(ctx context.Context, request api.TransitionTaskRequestObject) (api.TransitionTaskResponseObject, error) {
+	status, err := fromAPIStatus("TransitionTask", request.Body.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	completion, err := srv.store.TransitionTask(withActor(ctx, request.Params.XActor), request.Id, status)
+	if err != nil {
+		return nil, err
+	}
+	return completionResponse{completion}, nil
+}
+
+// ReopenTask moves a task back to open. Reopening an open task changes
+// nothing.
+func (srv *Server) ReopenTask(ctx context.Context, request api.ReopenTaskRequestObject) (api.ReopenTaskResponseObject, error) {
+	task, err := srv.store.ReopenTask(withActor(ctx, request.Params.XActor), request.Id)
+	if err != nil {
+		return nil, err
+	}
+	return api.ReopenTask200JSONResponse(toAPITask(task)), nil
+}
+
+// withActor attributes the changes of status made with ctx to the X-Actor
+// header, when the request sent one.
+func withActor(ctx context.Context, actor *api.Actor) context.Context {
+	if actor == nil {
+		return ctx
+	}
+	return store.WithActor(ctx, *actor)
+}
+
+// completionResponse only sends the next occurrence headers when one was
+// created; the generated responses always set them.
+type completionResponse struct {
+	store.Completion
+}
+
+func (response completionResponse) VisitCompleteTaskResponse(w http.ResponseWriter) error {
+	return response.write(w)
+}
+
+func (response completionResponse) VisitTransitionTaskResponse(w http.ResponseWriter) error {
+	return response.write(w)
+}
+
+func (response completionResponse) write(w http.ResponseWriter) error {
+	if next := response.Next; next != nil {
+		w.Header().Set("Link", fmt.Sprintf(`</tasks/%s>; rel="next"`, next.ID))
+		w.Header().Set("X-Next-Occurrence", next.ID.String())
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	return json.NewEncoder(w).Encode(toAPITask(response.Task))
+}
+
+func toAPITask(t togo.Task) api.Task {
+	task := api.Task{
+		Id:          t.ID,
+		Name:        t.Name,
+		Description: &t.Description,
+		Priority:    toAPIPriority(t.Priority),
+		ProjectId:   t.ProjectID,
+		ParentId:    t.ParentID,
+		Created:     t.Created,
+		Completed:   t.Completed,
+		Status:      toAPIStatus(t.Status),
+	}
+	if len(t.Transitions) > 0 {
+		transitions := make([]api.Transition, 0, len(t.Transitions))
+		for _, transition := range t.Transitions {
+			apiTransition := api.Transition{
+				From: toAPIStatus(transition.From),
+				To:   toAPIStatus(transition.To),
+				At:   transition.At,
+			}
+			if transition.By != "" {
+				by := transition.By
+				apiTransition.By = &by
+			}
+			transitions = append(transitions, apiTransition)
+		}
+		task.Transitions = &transitions
+	}
+	if t.DueDate != nil {
+		zone := t.DueDate.Location().String()
+		task.DueDate = &openapi_types.Date{Time: *t.DueDate}
+		task.DueZone = &zone
+		if t.HasDueTime {
+			dueTime := t.DueDate.Format(dueTimeLayout)
+			task.DueTime = &dueTime
+		}
+	}
+	if t.Recurrence != nil {
+		recurrence := t.Recurrence.String()
+		task.Recurrence = &recurrence
+	}
+	return task
+}
+
+func toAPITasks(ts []togo.Task) []api.Task {
+	tasks := make([]api.Task, 0, len(ts))
+	for _, t := range ts {
+		tasks = append(tasks, toAPITask(t))
+	}
+	return tasks
+}
+
+// applyTaskInput copies the editable fields of input onto t. The creation time
+// is only replaced when input sets it.
+func applyTaskInput(op string, t *togo.Task, input api.TaskInput) error {
+	t.Name = input.Name
+	if input.Created != nil {
+		t.Created = *input.Created
+	}
+	t.Description = ""
+	if input.Description != nil {
+		t.Description = *input.Description
+	}
+
+	t.Priority = togo.None
+	if input.Priority != nil {
+		priority, err := fromAPIPriority(op, *input.Priority)
+		if err != nil {
+			return err
+		}
+		t.Priority = priority
+	}
+
+	if err := applyDueDate(op, t, input); err != nil {
+		return err
+	}
+
+	t.ProjectID = input.ProjectId
+	t.ParentID = input.ParentId
+
+	t.Recurrence = nil
+	if input.Recurrence != nil {
+		recurrence, err := togo.ParseRecurrence(*input.Recurrence)
+		if err != nil {
+			return store.Invalid(op, err)
+		}
+		t.Recurrence = &recurrence
+	}
+	return nil
+}
+
+// applyStatus sets the completion time and status of a new task from input.
+// The status defaults to completed for tasks with a completion time and to
+// open for the rest. Transitions are never read from input, so the history of
+// a task only holds changes the server made.
+func applyStatus(op string, t *togo.Task, input api.TaskInput) error {
+	t.Completed = input.Completed
+	t.Status = togo.Open
+	if input.Completed != nil {
+		t.Status = togo.Completed
+	}
+	if input.Status != nil {
+		status, err := fromAPIStatus(op, *input.Status)
+		if err != nil {
+			return err
+		}
+		t.Status = status
+	}
+	return nil
+}
+
+// keepStatus checks that input doesn't ask to change the status of the saved
+// task t.
+func keepStatus(op string, t togo.Task, input api.TaskInput) error {
+	if input.Status == nil {
+		return nil
+	}
+	status, err := fromAPIStatus(op, *input.Status)
+	if err != nil {
+		return err
+	}
+	if status != t.Status {
+		return store.StatusChanged(op, t.Status, status)
+	}
+	return nil
+}
+
+// dueTimeLayout formats due times, always as hours and minutes.
+const dueTimeLayout = "15:04"
+
+// applyDueDate sets t's due date from the date, time and time zone in input,
+// which are only valid together with a date.
+func applyDueDate(op string, t *togo.Task, input api.TaskInput) error {
+	t.DueDate, t.HasDueTime = nil, false
+	if input.DueDate == nil {
+		if input.DueTime != nil || input.DueZone != nil {
+			return store.Invalid(op, errors.New("dueTime and dueZone need a dueDate"))
+		}
+		return nil
+	}
+
+	zone := time.UTC
+	if input.DueZone != nil {
+		var err error
+		if zone, err = togo.LoadZone(*input.DueZone); err != nil {
+			return store.Invalid(op, err)
+		}
+	}
+
+	yyyy, mm, dd := input.DueDate.Date()
+	if input.DueTime == nil {
+		t.AddDueDate(time.Date(yyyy, mm, dd, 0, 0, 0, 0, zone))
+		return nil
+	}
+	dueTime, err := time.Parse(dueTimeLayout, *input.DueTime)
+	if err != nil {
+		return store.Invalid(op, fmt.Errorf("dueTime %q is not a time of day like 17:30", *input.DueTime))
+	}
+	t.AddDueTime(time.Date(yyyy, mm, dd, dueTime.Hour(), dueTime.Minute(), 0, 0, zone))
+	return nil
+}
+
+func toAPIPriority(p togo.Priority) api.Priority {
+	switch p {
+	case togo.Low:
+		return api.Low
+	case togo.Medium:
+		return api.Medium
+	case togo.High:
+		return api.High
+	default:
+		return api.None
+	}
+}
+
+func fromAPIPriority(op string, p api.Priority) (togo.Priority, error) {
+	switch p {
+	case api.None:
+		return togo.None, nil
+	case api.Low:
+		return togo.Low, nil
+	case api.Medium:
+		return togo.Medium, nil
+	case api.High:
+		return togo.High, nil
+	default:
+		return togo.None, store.Invalid(op, fmt.Errorf("unknown priority %q", p))
+	}
+}
+
+// toAPIStatus relies on the API using the same names as togo.Status.String.
+func toAPIStatus(s togo.Status) api.Status {
+	return api.Status(s.String())
+}
+
+func fromAPIStatus(op string, s api.Status) (togo.Status, error) {
+	status, err := togo.ParseStatus(string(s))
+	if err != nil {
+		return togo.Open, store.Invalid(op, err)
+	}
+	return status, nil
+}
@@ -0,0 +1,492 @@
+package server
+
+import (
+	"fmt"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	"github.com/google/uuid"
+	"github.com/jaswdr/faker"
+	"github.com/peschkaj/togo/api"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTaskLifecycle(t *testing.T) {
+	ts := newTestServer(t)
+	f := faker.New()
+
+	description := f.Lorem().Sentence(5)
+	priority := api.High
+	due := openapi_types.Date{Time: time.Date(2030, time.March, 4, 0, 0, 0, 0, time.UTC)}
+	input := api.TaskInput{Name: f.UUID().V4(), Description: &description, Priority: &priority, DueDate: &due}
+
+	var created api.Task
+	expectStatus(t, do(t, ts, http.MethodPost, "/tasks", input, &created), http.StatusCreated)
+	if created.Id == uuid.Nil {
+		t.Fatal("created task has no ID")
+	}
+	if created.Name != input.Name || created.Priority != api.High || created.DueDate == nil || !created.DueDate.Equal(due.Time) {
+		t.Errorf("created task does not match the input: %+v", created)
+	}
+
+	path := "/tasks/" + created.Id.String()
+
+	var found api.Task
+	expectStatus(t, do(t, ts, http.MethodGet, path, nil, &found), http.StatusOK)
+	if found.Id != created.Id || found.Name != created.Name {
+		t.Errorf("expected %+v found %+v", created, found)
+	}
+
+	input.Name = f.UUID().V4()
+	input.DueDate = nil
+	var updated api.Task
+	expectStatus(t, do(t, ts, http.MethodPut, path, input, &updated), http.StatusOK)
+	if updated.Id != created.Id || updated.Name != input.Name || updated.DueDate != nil {
+		t.Errorf("task was not updated: %+v", updated)
+	}
+
+	var completed api.Task
+	expectStatus(t, do(t, ts, http.MethodPost, path+"/complete", nil, &completed), http.StatusOK)
+	if completed.Completed == nil {
+		t.Fatal("task was not completed")
+	}
+
+	var again api.Task
+	expectStatus(t, do(t, ts, http.MethodPost, path+"/complete", nil, &again), http.StatusOK)
+	if again.Completed == nil || !again.Completed.Equal(*completed.Completed) {
+		t.Error("completing a completed task changed its completion time")
+	}
+
+	var all []api.Task
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks", nil, &all), http.StatusOK)
+	if len(all) != 1 {
+		t.Errorf("expected %d tasks found %d", 1, len(all))
+	}
+
+	expectStatus(t, do(t, ts, http.MethodDelete, path, nil, nil), http.StatusNoContent)
+	expectStatus(t, do(t, ts, http.MethodGet, path, nil, nil), http.StatusNotFound)
+}
+
+func TestMissingTaskIsNotFound(t *testing.T) {
+	ts := newTestServer(t)
+	path := "/tasks/" + uuid.New().String()
+
+	expectStatus(t, do(t, ts, http.MethodGet, path, nil, nil), http.StatusNotFound)
+	expectStatus(t, do(t, ts, http.MethodDelete, path, nil, nil), http.StatusNotFound)
+	expectStatus(t, do(t, ts, http.MethodPost, path+"/complete", nil, nil), http.StatusNotFound)
+}
+
+func TestPutCreatesMissingTasks(t *testing.T) {
+	ts := newTestServer(t)
+	id := uuid.New()
+	path := "/tasks/" + id.String()
+
+	completed := time.Now().Add(-time.Hour).Truncate(time.Second)
+	var task api.Task
+	expectStatus(t, do(t, ts, http.MethodPut, path, api.TaskInput{Name: "created", Completed: &completed}, &task), http.StatusCreated)
+	if task.Id != id {
+		t.Errorf("expected ID %v found %v", id, task.Id)
+	}
+	if task.Completed == nil || !task.Completed.Equal(completed) {
+		t.Errorf("expected completion time %v found %v", completed, task.Completed)
+	}
+
+	// leaving out the completion time doesn't reopen the task
+	var renamed api.Task
+	expectStatus(t, do(t, ts, http.MethodPut, path, api.TaskInput{Name: "renamed"}, &renamed), http.StatusOK)
+	if renamed.Name != "renamed" || renamed.Completed == nil || !renamed.Completed.Equal(completed) {
+		t.Errorf("expected the task to be renamed and stay completed found %+v", renamed)
+	}
+
+	// reopening goes through /reopen, not PUT
+	open := api.Open
+	expectStatus(t, do(t, ts, http.MethodPut, path, api.TaskInput{Name: "reopened", Status: &open}, nil), http.StatusConflict)
+	var found api.Task
+	expectStatus(t, do(t, ts, http.MethodGet, path, nil, &found), http.StatusOK)
+	if found.Completed == nil || found.Name != "renamed" {
+		t.Errorf("a rejected PUT changed the task: %+v", found)
+	}
+}
+
+func TestPutKeepsTheStatusHistory(t *testing.T) {
+	ts := newTestServer(t)
+	path := "/tasks/" + uuid.New().String()
+
+	mallory := "mallory"
+	forged := []api.Transition{{From: api.Open, To: api.Completed, At: time.Now(), By: &mallory}}
+	var created api.Task
+	expectStatus(t, do(t, ts, http.MethodPut, path, api.TaskInput{Name: "created", Transitions: &forged}, &created), http.StatusCreated)
+	if created.Transitions != nil && len(*created.Transitions) > 0 {
+		t.Errorf("expected no transitions found %+v", *created.Transitions)
+	}
+
+	expectStatus(t, do(t, ts, http.MethodPost, path+"/status", api.StatusChange{Status: api.Cancelled}, nil), http.StatusOK)
+
+	// cancelled tasks can't be completed, by PUT or otherwise
+	completed := api.Completed
+	expectStatus(t, do(t, ts, http.MethodPut, path, api.TaskInput{Name: "completed", Status: &completed}, nil), http.StatusConflict)
+
+	var updated api.Task
+	expectStatus(t, do(t, ts, http.MethodPut, path, api.TaskInput{Name: "updated"}, &updated), http.StatusOK)
+	if updated.Status != api.Cancelled {
+		t.Errorf("expected status %v found %v", api.Cancelled, updated.Status)
+	}
+	if updated.Transitions == nil || len(*updated.Transitions) != 1 || (*updated.Transitions)[0].To != api.Cancelled {
+		t.Errorf("expected only the cancellation found %+v", updated.Transitions)
+	}
+}
+
+func TestTasksCanBeFiltered(t *testing.T) {
+	ts := newTestServer(t)
+
+	var project api.Project
+	expectStatus(t, do(t, ts, http.MethodPut, "/projects/work", api.ProjectInput{}, &project), http.StatusCreated)
+
+	today := openapi_types.Date{Time: time.Now().UTC()}
+	later := openapi_types.Date{Time: time.Now().UTC().AddDate(0, 0, 3)}
+	inputs := []api.TaskInput{
+		{Name: "today", DueDate: &today},
+		{Name: "later", DueDate: &later},
+		{Name: "undated", ProjectId: &project.Id},
+	}
+	for _, input := range inputs {
+		expectStatus(t, do(t, ts, http.MethodPost, "/tasks", input, nil), http.StatusCreated)
+	}
+
+	testCases := []struct {
+		query    string
+		expected []string
+	}{
+		{query: "", expected: []string{"today", "later", "undated"}},
+		{query: "?name=later", expected: []string{"later"}},
+		{query: "?dueOn=" + today.String(), expected: []string{"today"}},
+		{query: "?undated=true", expected: []string{"undated"}},
+		{query: "?dueFrom=" + today.String() + "&dueTo=" + later.String(), expected: []string{"today", "later"}},
+		{query: "?projectId=" + project.Id.String(), expected: []string{"undated"}},
+	}
+
+	for _, testCase := range testCases {
+		var tasks []api.Task
+		expectStatus(t, do(t, ts, http.MethodGet, "/tasks"+testCase.query, nil, &tasks), http.StatusOK)
+
+		found := map[string]bool{}
+		for _, task := range tasks {
+			found[task.Name] = true
+		}
+		if len(tasks) != len(testCase.expected) {
+			t.Errorf("%q: expected %v found %d tasks", testCase.query, testCase.expected, len(tasks))
+		}
+		for _, name := range testCase.expected {
+			if !found[name] {
+				t.Errorf("%q: expected %q", testCase.query, name)
+			}
+		}
+	}
+
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks?name=x&undated=true", nil, nil), http.StatusBadRequest)
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks?dueFrom="+today.String(), nil, nil), http.StatusBadRequest)
+
+	var count api.TaskCount
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/count", nil, &count), http.StatusOK)
+	if count.Count != 3 {
+		t.Errorf("expected %d tasks found %d", 3, count.Count)
+	}
+
+	var upcoming []api.Task
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/upcoming?days=1", nil, &upcoming), http.StatusOK)
+	if len(upcoming) != 1 || upcoming[0].Name != "today" {
+		t.Errorf("expected only today's task to be upcoming, found %v", upcoming)
+	}
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/upcoming?days=-1", nil, nil), http.StatusBadRequest)
+
+	var overdue []api.Task
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/overdue", nil, &overdue), http.StatusOK)
+	for _, task := range overdue {
+		if task.Name != "today" {
+			t.Errorf("task %q is not overdue", task.Name)
+		}
+	}
+}
+
+func TestDueDatesHaveTimeZones(t *testing.T) {
+	ts := newTestServer(t)
+
+	due := openapi_types.Date{Time: time.Date(2030, time.January, 8, 0, 0, 0, 0, time.UTC)}
+	dueTime, dueZone := "23:30", "America/Los_Angeles"
+	var created api.Task
+	expectStatus(t, do(t, ts, http.MethodPost, "/tasks", api.TaskInput{Name: "Call home", DueDate: &due, DueTime: &dueTime, DueZone: &dueZone}, &created), http.StatusCreated)
+	if created.DueDate == nil || created.DueDate.String() != "2030-01-08" {
+		t.Errorf("expected the task to be due on %s found %v", "2030-01-08", created.DueDate)
+	}
+	if created.DueTime == nil || *created.DueTime != dueTime || created.DueZone == nil || *created.DueZone != dueZone {
+		t.Errorf("expected the task to be due at %s in %s found %v in %v", dueTime, dueZone, created.DueTime, created.DueZone)
+	}
+
+	// 23:30 in Los Angeles is already the next day in UTC
+	testCases := []struct {
+		query string
+		found bool
+	}{
+		{query: "?dueOn=2030-01-08", found: false},
+		{query: "?dueOn=2030-01-09", found: true},
+		{query: "?dueOn=2030-01-08&tz=America/Los_Angeles", found: true},
+		{query: "?dueFrom=2030-01-01&dueTo=2030-01-08&tz=America/Los_Angeles", found: true},
+	}
+	for _, testCase := range testCases {
+		var tasks []api.Task
+		expectStatus(t, do(t, ts, http.MethodGet, "/tasks"+testCase.query, nil, &tasks), http.StatusOK)
+		if found := len(tasks) == 1; found != testCase.found {
+			t.Errorf("%q: expected the task to be found: %v, found %d tasks", testCase.query, testCase.found, len(tasks))
+		}
+	}
+
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks?dueOn=2030-01-08&tz=Mars/Olympus_Mons", nil, nil), http.StatusBadRequest)
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/overdue?tz=Local", nil, nil), http.StatusBadRequest)
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/upcoming?tz=Mars/Olympus_Mons", nil, nil), http.StatusBadRequest)
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/search?dueFrom=2030-01-08&tz=Mars/Olympus_Mons", nil, nil), http.StatusBadRequest)
+}
+
+func TestTasksArePaged(t *testing.T) {
+	ts := newTestServer(t)
+	for i := 0; i < 5; i++ {
+		expectStatus(t, do(t, ts, http.MethodPost, "/tasks", api.TaskInput{Name: fmt.Sprint("task ", i)}, nil), http.StatusCreated)
+	}
+
+	seen := map[uuid.UUID]bool{}
+	path := "/tasks?limit=2"
+	for pages := 1; ; pages++ {
+		var tasks []api.Task
+		resp := do(t, ts, http.MethodGet, path, nil, &tasks)
+		expectStatus(t, resp, http.StatusOK)
+		for _, task := range tasks {
+			if seen[task.Id] {
+				t.Errorf("task %v returned twice", task.Id)
+			}
+			seen[task.Id] = true
+		}
+
+		next := resp.Header.Get("X-Next-Cursor")
+		if next == "" {
+			if pages != 3 {
+				t.Errorf("expected %d pages found %d", 3, pages)
+			}
+			if link := resp.Header.Get("Link"); link != "" {
+				t.Errorf("expected no link on the last page found %q", link)
+			}
+			break
+		}
+		if link := resp.Header.Get("Link"); !strings.Contains(link, next) || !strings.HasSuffix(link, `rel="next"`) {
+			t.Errorf("expected a next link to %q found %q", next, link)
+		}
+		path = "/tasks?limit=2&cursor=" + next
+	}
+	if len(seen) != 5 {
+		t.Errorf("expected %d tasks found %d", 5, len(seen))
+	}
+
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks?limit=0", nil, nil), http.StatusBadRequest)
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks?cursor=not-a-cursor", nil, nil), http.StatusBadRequest)
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks?limit=2&name=x", nil, nil), http.StatusBadRequest)
+}
+
+func TestTasksCanBeSearched(t *testing.T) {
+	ts := newTestServer(t)
+
+	high, low := api.High, api.Low
+	description := "pick plants for the patio"
+	inputs := []api.TaskInput{
+		{Name: "Call the bank", Priority: &high},
+		{Name: "Water the plants", Priority: &low},
+		{Name: "Book a trip", Description: &description, Priority: &high},
+	}
+	for _, input := range inputs {
+		expectStatus(t, do(t, ts, http.MethodPost, "/tasks", input, nil), http.StatusCreated)
+	}
+
+	testCases := []struct {
+		query    string
+		expected []string
+	}{
+		{query: "?minPriority=high&sort=title", expected: []string{"Book a trip", "Call the bank"}},
+		{query: "?q=PLANT&sort=-title", expected: []string{"Water the plants", "Book a trip"}},
+		{query: "?sort=-priority,title", expected: []string{"Book a trip", "Call the bank", "Water the plants"}},
+		{query: "?completed=true", expected: []string{}},
+		{query: "?status=open,blocked&sort=title", expected: []string{"Book a trip", "Call the bank", "Water the plants"}},
+		{query: "?status=in_progress", expected: []string{}},
+	}
+
+	for _, testCase := range testCases {
+		var tasks []api.Task
+		expectStatus(t, do(t, ts, http.MethodGet, "/tasks/search"+testCase.query, nil, &tasks), http.StatusOK)
+
+		found := make([]string, 0, len(tasks))
+		for _, task := range tasks {
+			found = append(found, task.Name)
+		}
+		if strings.Join(found, "|") != strings.Join(testCase.expected, "|") {
+			t.Errorf("%q: expected %v found %v", testCase.query, testCase.expected, found)
+		}
+	}
+
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/search?sort=name", nil, nil), http.StatusBadRequest)
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/search?minPriority=urgent", nil, nil), http.StatusBadRequest)
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/search?status=done", nil, nil), http.StatusBadRequest)
+}
+
+func TestCompletingRecurringTasksLinksTheNextOccurrence(t *testing.T) {
+	ts := newTestServer(t)
+
+	recurrence := "FREQ=MONTHLY;BYDAY=-1FR;COUNT=2"
+	due := openapi_types.Date{Time: time.Date(2030, time.January, 25, 0, 0, 0, 0, time.UTC)}
+	var created api.Task
+	expectStatus(t, do(t, ts, http.MethodPost, "/tasks", api.TaskInput{Name: "Pay rent", DueDate: &due, Recurrence: &recurrence}, &created), http.StatusCreated)
+	if created.Recurrence == nil || *created.Recurrence != recurrence {
+		t.Fatalf("expected recurrence %q found %v", recurrence, created.Recurrence)
+	}
+
+	resp := do(t, ts, http.MethodPost, "/tasks/"+created.Id.String()+"/complete", nil, nil)
+	expectStatus(t, resp, http.StatusOK)
+	next := resp.Header.Get("X-Next-Occurrence")
+	if next == "" {
+		t.Fatal("expected the next occurrence to be linked")
+	}
+	if link := resp.Header.Get("Link"); link != fmt.Sprintf(`</tasks/%s>; rel="next"`, next) {
+		t.Errorf("expected a next link to %q found %q", next, link)
+	}
+
+	var occurrence api.Task
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/"+next, nil, &occurrence), http.StatusOK)
+	if occurrence.DueDate == nil || !occurrence.DueDate.Equal(time.Date(2030, time.February, 22, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the next occurrence on the last Friday of February found %v", occurrence.DueDate)
+	}
+	if occurrence.Recurrence == nil || *occurrence.Recurrence != "FREQ=MONTHLY;BYDAY=-1FR;COUNT=1" {
+		t.Errorf("expected one occurrence left found %v", occurrence.Recurrence)
+	}
+
+	resp = do(t, ts, http.MethodPost, "/tasks/"+next+"/complete", nil, nil)
+	expectStatus(t, resp, http.StatusOK)
+	if header := resp.Header.Get("X-Next-Occurrence"); header != "" {
+		t.Errorf("expected the series to end found %q", header)
+	}
+	if link := resp.Header.Get("Link"); link != "" {
+		t.Errorf("expected no link found %q", link)
+	}
+}
+
+func TestTasksMoveThroughStatuses(t *testing.T) {
+	ts := newTestServer(t)
+
+	var task api.Task
+	expectStatus(t, do(t, ts, http.MethodPost, "/tasks", api.TaskInput{Name: "Write the report"}, &task), http.StatusCreated)
+	if task.Status != api.Open || task.Transitions != nil {
+		t.Fatalf("expected a new task to be open without transitions, found %v %v", task.Status, task.Transitions)
+	}
+	path := "/tasks/" + task.Id.String() + "/status"
+
+	for _, status := range []api.Status{api.InProgress, api.Blocked, api.Completed} {
+		expectStatus(t, do(t, ts, http.MethodPost, path, api.StatusChange{Status: status}, &task), http.StatusOK)
+		if task.Status != status {
+			t.Errorf("expected the task to be %v found %v", status, task.Status)
+		}
+	}
+	if task.Completed == nil {
+		t.Error("expected the task to have a completion time")
+	}
+	if task.Transitions == nil || len(*task.Transitions) != 3 {
+		t.Fatalf("expected 3 transitions found %v", task.Transitions)
+	}
+	if last := (*task.Transitions)[2]; last.From != api.Blocked || last.To != api.Completed || !last.At.Equal(*task.Completed) {
+		t.Errorf("expected the last transition to complete the task, found %+v", last)
+	}
+
+	expectStatus(t, do(t, ts, http.MethodPost, path, api.StatusChange{Status: api.Waiting}, nil), http.StatusConflict)
+	expectStatus(t, do(t, ts, http.MethodPost, path, api.StatusChange{Status: "done"}, nil), http.StatusBadRequest)
+	expectStatus(t, do(t, ts, http.MethodPost, "/tasks/"+uuid.New().String()+"/status", api.StatusChange{Status: api.Open}, nil), http.StatusNotFound)
+
+	var reopened api.Task
+	expectStatus(t, do(t, ts, http.MethodPost, path, api.StatusChange{Status: api.Open}, &reopened), http.StatusOK)
+	if reopened.Status != api.Open || reopened.Completed != nil {
+		t.Errorf("expected the task to be reopened, found %v completed at %v", reopened.Status, reopened.Completed)
+	}
+
+	var tasks []api.Task
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/search?status=open", nil, &tasks), http.StatusOK)
+	if len(tasks) != 1 || tasks[0].Id != task.Id {
+		t.Errorf("expected to find the reopened task, found %v", tasks)
+	}
+}
+
+func TestReopeningKeepsACompletionHistory(t *testing.T) {
+	ts := newTestServer(t)
+
+	var task api.Task
+	expectStatus(t, do(t, ts, http.MethodPost, "/tasks", api.TaskInput{Name: "File taxes"}, &task), http.StatusCreated)
+	id := task.Id.String()
+
+	asActor := func(path, actor string) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPost, ts.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-Actor", actor)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		expectStatus(t, resp, http.StatusOK)
+	}
+	asActor("/tasks/"+id+"/complete", "alice")
+	asActor("/tasks/"+id+"/reopen", "bob")
+
+	var reopened api.Task
+	expectStatus(t, do(t, ts, http.MethodGet, "/tasks/"+id, nil, &reopened), http.StatusOK)
+	if reopened.Status != api.Open || reopened.Completed != nil {
+		t.Errorf("expected the task to be reopened, found %v completed at %v", reopened.Status, reopened.Completed)
+	}
+	if reopened.Transitions == nil || len(*reopened.Transitions) != 2 {
+		t.Fatalf("expected 2 transitions found %v", reopened.Transitions)
+	}
+	for i, actor := range []string{"alice", "bob"} {
+		if by := (*reopened.Transitions)[i].By; by == nil || *by != actor {
+			t.Errorf("expected transition %d to be made by %s found %v", i+1, actor, by)
+		}
+	}
+
+	// reopening an open task changes nothing
+	expectStatus(t, do(t, ts, http.MethodPost, "/tasks/"+id+"/reopen", nil, &reopened), http.StatusOK)
+	if len(*reopened.Transitions) != 2 {
+		t.Errorf("expected reopening an open task to change nothing, found %d transitions", len(*reopened.Transitions))
+	}
+	expectStatus(t, do(t, ts, http.MethodPost, "/tasks/"+uuid.New().String()+"/reopen", nil, nil), http.StatusNotFound)
+}
+
+func TestInvalidTasksAreRejected(t *testing.T) {
+	ts := newTestServer(t)
+	unknown := api.Priority("urgent")
+	project := uuid.New()
+	unsupported := "FREQ=HOURLY"
+	due := openapi_types.Date{Time: time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	weekly := "FREQ=WEEKLY"
+	local, lateNight, lunch := "Local", "25:00", "12:30"
+	done, completed := api.Status("done"), api.Completed
+
+	testCases := []api.TaskInput{
+		{Name: ""},
+		{Name: "unknown priority", Priority: &unknown},
+		{Name: "unknown project", ProjectId: &project},
+		{Name: "unsupported recurrence", DueDate: &due, Recurrence: &unsupported},
+		{Name: "recurring without a due date", Recurrence: &weekly},
+		{Name: "local due zone", DueDate: &due, DueZone: &local},
+		{Name: "unknown due time", DueDate: &due, DueTime: &lateNight},
+		{Name: "due time without a due date", DueTime: &lunch},
+		{Name: "unknown status", Status: &done},
+		{Name: "completed without a completion time", Status: &completed},
+	}
+
+	for _, input := range testCases {
+		expectStatus(t, do(t, ts, http.MethodPost, "/tasks", input, nil), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,111 @@
+package togo
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Comparator orders two tasks. It returns a negative number when a sorts
+// before b, a positive number when a sorts after b, and zero when they're
+// tied.
+type Comparator func(a, b Task) int
+
+// ByTitle orders tasks by name, comparing bytes rather than letters.
+func ByTitle(a, b Task) int {
+	return strings.Compare(a.Name, b.Name)
+}
+
+// ByDueDate orders tasks by due date, earliest first. Tasks without a due date
+// sort after every dated task.
+func ByDueDate(a, b Task) int {
+	if c, ok := undatedLast(a, b); ok {
+		return c
+	}
+	return compareTimes(*a.DueDate, *b.DueDate)
+}
+
+// ByDueDateDescending orders tasks by due date, latest first. Tasks without a
+// due date still sort after every dated task, which Reverse(ByDueDate) would
+// not do.
+func ByDueDateDescending(a, b Task) int {
+	if c, ok := undatedLast(a, b); ok {
+		return c
+	}
+	return compareTimes(*b.DueDate, *a.DueDate)
+}
+
+// ByPriority orders tasks by priority, least important first.
+func ByPriority(a, b Task) int {
+	return int(a.Priority) - int(b.Priority)
+}
+
+// ByCreated orders tasks by creation time, oldest first.
+func ByCreated(a, b Task) int {
+	return compareTimes(a.Created, b.Created)
+}
+
+// ByID orders tasks by ID. IDs are unique, so no two distinct tasks are tied.
+func ByID(a, b Task) int {
+	return strings.Compare(a.ID.String(), b.ID.String())
+}
+
+// ByPriorityAndDate puts the most important tasks first and orders tasks of
+// the same priority by due date.
+var ByPriorityAndDate = Then(Reverse(ByPriority), ByDueDate)
+
+// Reverse inverts the order of c.
+func Reverse(c Comparator) Comparator {
+	return func(a, b Task) int {
+		return c(b, a)
+	}
+}
+
+// Then orders tasks by each comparator in turn, moving on to the next one
+// only while the tasks are tied.
+func Then(cs ...Comparator) Comparator {
+	return func(a, b Task) int {
+		for _, c := range cs {
+			if result := c(a, b); result != 0 {
+				return result
+			}
+		}
+		return 0
+	}
+}
+
+// tiebreakers settle the order of tasks left tied by SortBy's comparators, so
+// that the same tasks are always sorted the same way.
+var tiebreakers = []Comparator{ByTitle, ByCreated, ByID}
+
+// SortBy sorts ts by each comparator in turn. Tasks still tied are ordered by
+// name, then by creation time, then by ID.
+func (ts Tasks) SortBy(cs ...Comparator) {
+	order := Then(append(append([]Comparator(nil), cs...), tiebreakers...)...)
+	sort.SliceStable(ts, func(i, j int) bool {
+		return order(ts[i], ts[j]) < 0
+	})
+}
+
+// undatedLast orders a and b when at least one of them has no due date.
+func undatedLast(a, b Task) (int, bool) {
+	switch {
+	case a.DueDate == nil && b.DueDate == nil:
+		return 0, true
+	case a.DueDate == nil:
+		return 1, true
+	case b.DueDate == nil:
+		return -1, true
+	}
+	return 0, false
+}
+
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
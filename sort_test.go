@@ -0,0 +1,84 @@
+package togo
+
+import (
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func dueIn(days int) *time.Time {
+	due := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, days)
+	return &due
+}
+
+func expectOrder(t *testing.T, got []Task, want ...Task) {
+	t.Helper()
+	for i := range want {
+		if got[i].ID != want[i].ID {
+			t.Fatalf("expected %q at position %d found %q", want[i].Name, i, got[i].Name)
+		}
+	}
+}
+
+func TestSortByTitle(t *testing.T) {
+	a := Task{ID: uuid.New(), Name: "a"}
+	b := Task{ID: uuid.New(), Name: "B"}
+	c := Task{ID: uuid.New(), Name: "c"}
+
+	ts := Tasks{c, a, b}
+	ts.SortBy(ByTitle)
+	// titles are compared byte by byte, upper case sorts first
+	expectOrder(t, ts, b, a, c)
+
+	ts.SortBy(Reverse(ByTitle))
+	expectOrder(t, ts, c, a, b)
+}
+
+func TestUndatedTasksSortLast(t *testing.T) {
+	undated := Task{ID: uuid.New(), Name: "undated"}
+	soon := Task{ID: uuid.New(), Name: "soon", DueDate: dueIn(1)}
+	later := Task{ID: uuid.New(), Name: "later", DueDate: dueIn(5)}
+
+	ts := Tasks{undated, later, soon}
+	ts.SortBy(ByDueDate)
+	expectOrder(t, ts, soon, later, undated)
+
+	ts.SortBy(ByDueDateDescending)
+	expectOrder(t, ts, later, soon, undated)
+}
+
+func TestSortByPriorityAndDate(t *testing.T) {
+	highLater := Task{ID: uuid.New(), Name: "high later", Priority: High, DueDate: dueIn(3)}
+	highSoon := Task{ID: uuid.New(), Name: "high soon", Priority: High, DueDate: dueIn(1)}
+	highUndated := Task{ID: uuid.New(), Name: "high undated", Priority: High}
+	low := Task{ID: uuid.New(), Name: "low", Priority: Low, DueDate: dueIn(0)}
+
+	ts := Tasks{low, highUndated, highLater, highSoon}
+	ts.SortBy(ByPriorityAndDate)
+	expectOrder(t, ts, highSoon, highLater, highUndated, low)
+}
+
+func TestTiesAreBrokenDeterministically(t *testing.T) {
+	created := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	first := Task{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Name: "same", Created: created}
+	second := Task{ID: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Name: "same", Created: created}
+	newer := Task{ID: uuid.MustParse("00000000-0000-0000-0000-000000000000"), Name: "same", Created: created.Add(time.Hour)}
+	other := Task{ID: uuid.New(), Name: "other", Created: created.Add(2 * time.Hour)}
+
+	for _, ts := range []Tasks{{newer, second, other, first}, {first, other, newer, second}} {
+		ts.SortBy(ByPriority)
+		expectOrder(t, ts, other, first, second, newer)
+	}
+}
+
+func TestThenUsesTheFirstDifference(t *testing.T) {
+	a := Task{Name: "a", Priority: High}
+	b := Task{Name: "b", Priority: High}
+
+	if c := Then(ByPriority, ByTitle)(a, b); c >= 0 {
+		t.Errorf("expected a before b, found %d", c)
+	}
+	if c := Then(ByPriority)(a, b); c != 0 {
+		t.Errorf("expected a tie, found %d", c)
+	}
+}
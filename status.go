@@ -0,0 +1,170 @@
+package togo
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Status is where a task stands. New tasks are Open; Completed and Cancelled
+// tasks are finished and have to be reopened before they can change again.
+type Status int
+
+const (
+	Open Status = iota
+	InProgress
+	Blocked
+	Waiting
+	Completed
+	Cancelled
+)
+
+var statusNames = map[Status]string{
+	Open:       "open",
+	InProgress: "in_progress",
+	Blocked:    "blocked",
+	Waiting:    "waiting",
+	Completed:  "completed",
+	Cancelled:  "cancelled",
+}
+
+func (s Status) String() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// ParseStatus reads a status in the form returned by Status.String.
+func ParseStatus(s string) (Status, error) {
+	for status, name := range statusNames {
+		if name == s {
+			return status, nil
+		}
+	}
+	return Open, fmt.Errorf("unknown status %q", s)
+}
+
+// Validate reports statuses outside of the known ones.
+func (s Status) Validate() error {
+	if _, ok := statusNames[s]; !ok {
+		return fmt.Errorf("unknown status %d", int(s))
+	}
+	return nil
+}
+
+// transitions lists the statuses each status can change to. A task that is
+// still being worked on can move to any other status; a finished task can
+// only be reopened.
+var transitions = map[Status][]Status{
+	Open:       {InProgress, Blocked, Waiting, Completed, Cancelled},
+	InProgress: {Open, Blocked, Waiting, Completed, Cancelled},
+	Blocked:    {Open, InProgress, Waiting, Completed, Cancelled},
+	Waiting:    {Open, InProgress, Blocked, Completed, Cancelled},
+	Completed:  {Open},
+	Cancelled:  {Open},
+}
+
+// IsFinished reports whether s is Completed or Cancelled.
+func (s Status) IsFinished() bool {
+	return s == Completed || s == Cancelled
+}
+
+// ErrIllegalTransition is returned when a task is moved to a status its
+// current status can't change to.
+var ErrIllegalTransition = errors.New("illegal status transition")
+
+// CanTransitionTo reports whether a task can move from s to to. A status
+// can't transition to itself.
+func (s Status) CanTransitionTo(to Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition records a task moving from one status to another.
+type Transition struct {
+	From Status
+	To   Status
+	At   time.Time
+	// By names who made the change, empty when it isn't known.
+	By string
+}
+
+// TransitionAt moves t to the status to at the time at and records the
+// transition. Moving to Completed sets the completion time, moving away from
+// it clears it.
+func (t *Task) TransitionAt(to Status, at time.Time) error {
+	return t.TransitionBy(to, at, "")
+}
+
+// TransitionBy moves t like TransitionAt and records by as who made the
+// change.
+func (t *Task) TransitionBy(to Status, at time.Time, by string) error {
+	if !t.Status.CanTransitionTo(to) {
+		return fmt.Errorf("%w from %v to %v", ErrIllegalTransition, t.Status, to)
+	}
+
+	// copy the history, tasks copied from t share its backing array
+	n := len(t.Transitions)
+	t.Transitions = append(t.Transitions[:n:n], Transition{From: t.Status, To: to, At: at, By: by})
+	t.Status = to
+	t.Completed = nil
+	if to == Completed {
+		t.Completed = &at
+	}
+	return nil
+}
+
+// Reopen moves t back to Open, undoing its completion or cancellation. Every
+// status other than Open can be reopened.
+func (t *Task) Reopen() error {
+	return t.ReopenAt(SystemClock.Now())
+}
+
+// ReopenAt reopens t like Reopen, at the time at.
+func (t *Task) ReopenAt(at time.Time) error {
+	return t.TransitionAt(Open, at)
+}
+
+// CompletionHistory returns the transitions that completed t or took its
+// completion back, oldest first.
+func (t *Task) CompletionHistory() []Transition {
+	var history []Transition
+	for _, transition := range t.Transitions {
+		if transition.From == Completed || transition.To == Completed {
+			history = append(history, transition)
+		}
+	}
+	return history
+}
+
+// ValidateStatus checks that t's status, completion time and transitions agree:
+// a task has a completion time exactly when it is Completed, and its
+// transitions are allowed, follow on from each other and end in its status.
+func (t *Task) ValidateStatus() error {
+	if err := t.Status.Validate(); err != nil {
+		return err
+	}
+	if (t.Status == Completed) != (t.Completed != nil) {
+		return errors.New("only completed tasks have a completion time")
+	}
+	for i, transition := range t.Transitions {
+		if !transition.From.CanTransitionTo(transition.To) {
+			return fmt.Errorf("%w from %v to %v", ErrIllegalTransition, transition.From, transition.To)
+		}
+		if i > 0 {
+			previous := t.Transitions[i-1]
+			if transition.From != previous.To || transition.At.Before(previous.At) {
+				return fmt.Errorf("transition %d doesn't follow on from the one before it", i+1)
+			}
+		}
+	}
+	if n := len(t.Transitions); n > 0 && t.Transitions[n-1].To != t.Status {
+		return fmt.Errorf("the last transition is to %v, not to the task's status %v", t.Transitions[n-1].To, t.Status)
+	}
+	return nil
+}
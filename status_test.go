@@ -0,0 +1,157 @@
+package togo
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStatusesRoundTrip(t *testing.T) {
+	for status := range statusNames {
+		parsed, err := ParseStatus(status.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != status {
+			t.Errorf("expected %v found %v", status, parsed)
+		}
+	}
+
+	if _, err := ParseStatus("done"); err == nil {
+		t.Error("expected an unknown status to be rejected")
+	}
+	if err := Status(42).Validate(); err == nil {
+		t.Error("expected an unknown status to be invalid")
+	}
+}
+
+func TestTransitionsFollowTheTable(t *testing.T) {
+	testCases := []struct {
+		from, to Status
+		allowed  bool
+	}{
+		{Open, InProgress, true},
+		{InProgress, Blocked, true},
+		{Blocked, Waiting, true},
+		{Waiting, Completed, true},
+		{Open, Cancelled, true},
+		{Completed, Open, true},
+		{Cancelled, Open, true},
+		{Open, Open, false},
+		{Blocked, Open, true},
+		{Completed, InProgress, false},
+		{Completed, Cancelled, false},
+		{Cancelled, Completed, false},
+		{Open, Status(42), false},
+	}
+
+	for _, testCase := range testCases {
+		if allowed := testCase.from.CanTransitionTo(testCase.to); allowed != testCase.allowed {
+			t.Errorf("%v to %v: expected allowed to be %v", testCase.from, testCase.to, testCase.allowed)
+		}
+	}
+}
+
+func TestTransitionsAreRecorded(t *testing.T) {
+	start := time.Date(2030, time.March, 14, 9, 30, 0, 0, time.UTC)
+	task := NewTaskAt(start, "name", "")
+
+	steps := []Status{InProgress, Blocked, InProgress, Completed}
+	for i, status := range steps {
+		if err := task.TransitionAt(status, start.Add(time.Duration(i+1)*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	completed := start.Add(4 * time.Hour)
+	if task.Status != Completed || task.Completed == nil || !task.Completed.Equal(completed) {
+		t.Errorf("expected the task to be completed at %v, found %v at %v", completed, task.Status, task.Completed)
+	}
+	if len(task.Transitions) != len(steps) {
+		t.Fatalf("expected %d transitions found %d", len(steps), len(task.Transitions))
+	}
+	from := Open
+	for i, transition := range task.Transitions {
+		at := start.Add(time.Duration(i+1) * time.Hour)
+		if transition.From != from || transition.To != steps[i] || !transition.At.Equal(at) {
+			t.Errorf("expected transition %d from %v to %v at %v, found %+v", i+1, from, steps[i], at, transition)
+		}
+		from = steps[i]
+	}
+	if err := task.ValidateStatus(); err != nil {
+		t.Error(err)
+	}
+
+	copied := task
+	if err := task.TransitionAt(InProgress, start.Add(5*time.Hour)); !errors.Is(err, ErrIllegalTransition) {
+		t.Errorf("expected %v found %v", ErrIllegalTransition, err)
+	}
+	if err := task.ReopenAt(start.Add(5 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if task.Status != Open || task.Completed != nil {
+		t.Errorf("expected the reopened task to be open, found %v completed at %v", task.Status, task.Completed)
+	}
+	if len(copied.Transitions) != len(steps) || copied.Status != Completed {
+		t.Error("reopening the task changed a copy of it")
+	}
+	if history := task.CompletionHistory(); len(history) != 2 || history[0].To != Completed || history[1].From != Completed {
+		t.Errorf("expected the completion and the reopening, found %+v", history)
+	}
+	if err := task.ReopenAt(start.Add(6 * time.Hour)); !errors.Is(err, ErrIllegalTransition) {
+		t.Errorf("expected reopening an open task to fail, found %v", err)
+	}
+}
+
+func TestInconsistentStatusesAreInvalid(t *testing.T) {
+	now := time.Date(2030, time.March, 14, 9, 30, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+
+	testCases := []struct {
+		name string
+		task Task
+	}{
+		{"unknown status", Task{Status: Status(42)}},
+		{"completed without a time", Task{Status: Completed}},
+		{"completion time while open", Task{Completed: &now}},
+		{"illegal transition", Task{Status: InProgress, Transitions: []Transition{{From: Completed, To: InProgress, At: now}}}},
+		{"broken chain", Task{Status: Blocked, Transitions: []Transition{
+			{From: Open, To: InProgress, At: now},
+			{From: Waiting, To: Blocked, At: later},
+		}}},
+		{"out of order", Task{Status: Blocked, Transitions: []Transition{
+			{From: Open, To: InProgress, At: later},
+			{From: InProgress, To: Blocked, At: now},
+		}}},
+		{"ends elsewhere", Task{Status: Waiting, Transitions: []Transition{{From: Open, To: InProgress, At: now}}}},
+	}
+
+	for _, testCase := range testCases {
+		if err := testCase.task.ValidateStatus(); err == nil {
+			t.Errorf("%s: expected the status to be invalid", testCase.name)
+		}
+	}
+
+	// tasks saved before statuses existed have no transitions
+	legacy := Task{Status: Completed, Completed: &now}
+	if err := legacy.ValidateStatus(); err != nil {
+		t.Errorf("expected a completed task without transitions to be valid, found %v", err)
+	}
+}
+
+func TestTransitionsRecordWhoMadeThem(t *testing.T) {
+	now := time.Date(2030, time.March, 14, 9, 30, 0, 0, time.UTC)
+	task := NewTaskAt(now, "name", "")
+
+	if err := task.TransitionBy(Completed, now, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := task.ReopenAt(now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	history := task.CompletionHistory()
+	if len(history) != 2 || history[0].By != "alice" || history[1].By != "" {
+		t.Errorf("expected the completion by alice and an unattributed reopening, found %+v", history)
+	}
+}
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+)
+
+// EncodeCursor returns an opaque cursor for the page that follows the task
+// with the given ID. Every backend orders listings by ID, so a cursor created
+// by one backend can be used with any other.
+func EncodeCursor(id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+// DecodeCursor returns the ID of the last task before the page selected by
+// cursor, or uuid.Nil for the first page.
+func DecodeCursor(op, cursor string) (uuid.UUID, error) {
+	if cursor == "" {
+		return uuid.Nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return uuid.Nil, Invalid(op, errors.New("malformed cursor"))
+	}
+	id, err := uuid.FromBytes(raw)
+	if err != nil {
+		return uuid.Nil, Invalid(op, errors.New("malformed cursor"))
+	}
+	return id, nil
+}
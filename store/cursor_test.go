@@ -0,0 +1,54 @@
+package store
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	id := uuid.New()
+
+	decoded, err := DecodeCursor("ListTasks", EncodeCursor(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded != id {
+		t.Errorf("expected %v found %v", id, decoded)
+	}
+}
+
+func TestEmptyCursorStartsAtTheBeginning(t *testing.T) {
+	id, err := DecodeCursor("ListTasks", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != uuid.Nil {
+		t.Errorf("expected %v found %v", uuid.Nil, id)
+	}
+}
+
+func TestMalformedCursorsAreInvalid(t *testing.T) {
+	for _, cursor := range []string{"not a cursor", "AAAA", uuid.NewString()} {
+		if _, err := DecodeCursor("ListTasks", cursor); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%q: expected %v found %v", cursor, ErrInvalid, err)
+		}
+	}
+}
+
+func TestPageSize(t *testing.T) {
+	testCases := []struct {
+		limit, size int
+	}{
+		{limit: -1, size: DefaultPageLimit},
+		{limit: 0, size: DefaultPageLimit},
+		{limit: 10, size: 10},
+		{limit: MaxPageLimit + 1, size: MaxPageLimit},
+	}
+
+	for _, testCase := range testCases {
+		if size := (Page{Limit: testCase.limit}).Size(); size != testCase.size {
+			t.Errorf("limit %d: expected %d found %d", testCase.limit, testCase.size, size)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/peschkaj/togo"
+)
+
+// MaxNameLength is the longest task or project name any backend is required
+// to store.
+const MaxNameLength = 100
+
+// Sentinel errors describing why a store operation failed. Backends never
+// return these directly; they return an *Error whose Kind is one of them, so
+// callers should test with errors.Is.
+var (
+	ErrNotFound = errors.New("not found")
+	ErrConflict = errors.New("conflict")
+	ErrInvalid  = errors.New("invalid")
+	ErrTimeout  = errors.New("timeout")
+)
+
+// Error is returned by every Store implementation when an operation fails for
+// a reason callers are expected to handle. The backend specific cause, if
+// there is one, is kept in Err and remains reachable through errors.Is and
+// errors.As.
+type Error struct {
+	Op   string
+	Kind error
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("store: %s: %v", e.Op, e.Kind)
+	}
+	return fmt.Sprintf("store: %s: %v: %v", e.Op, e.Kind, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the sentinel describing this error.
+func (e *Error) Is(target error) bool {
+	return e.Kind == target
+}
+
+func NotFound(op string, err error) error {
+	return &Error{Op: op, Kind: ErrNotFound, Err: err}
+}
+
+func Conflict(op string, err error) error {
+	return &Error{Op: op, Kind: ErrConflict, Err: err}
+}
+
+func Invalid(op string, err error) error {
+	return &Error{Op: op, Kind: ErrInvalid, Err: err}
+}
+
+// Timeout reports an operation the backend gave up on because it ran too long,
+// such as a Postgres statement timeout. Deadlines set on the caller's context
+// are reported as the context's error instead.
+func Timeout(op string, err error) error {
+	return &Error{Op: op, Kind: ErrTimeout, Err: err}
+}
+
+// ValidateTask checks the rules every backend enforces before saving a task.
+func ValidateTask(op string, t togo.Task) error {
+	if t.ID == uuid.Nil {
+		return Invalid(op, errors.New("task ID is required"))
+	}
+	if t.Name == "" {
+		return Invalid(op, errors.New("task name is required"))
+	}
+	if len(t.Name) > MaxNameLength {
+		return Invalid(op, fmt.Errorf("task name is longer than %d characters", MaxNameLength))
+	}
+	if t.ParentID != nil && *t.ParentID == t.ID {
+		return Invalid(op, errors.New("a task can't be its own subtask"))
+	}
+	if err := t.ValidateStatus(); err != nil {
+		return Invalid(op, err)
+	}
+	if t.HasDueTime && t.DueDate == nil {
+		return Invalid(op, errors.New("a due time needs a due date"))
+	}
+	if t.DueDate != nil {
+		// backends that keep the zone by name have to be able to load it again
+		if _, err := togo.LoadZone(t.DueDate.Location().String()); err != nil {
+			return Invalid(op, fmt.Errorf("due date: %w", err))
+		}
+	}
+	if t.Recurrence != nil {
+		if t.DueDate == nil {
+			return Invalid(op, errors.New("a recurring task needs a due date"))
+		}
+		if err := t.Recurrence.Validate(); err != nil {
+			return Invalid(op, err)
+		}
+	}
+	return nil
+}
+
+// StatusChanged reports an AddOrUpdateTask that would move a saved task from
+// one status to another without going through TransitionTask.
+func StatusChanged(op string, saved, status togo.Status) error {
+	return Conflict(op, fmt.Errorf("the task is %v, move it to %v with TransitionTask", saved, status))
+}
+
+// ValidateRemoveRule rejects unknown remove rules.
+func ValidateRemoveRule(op string, rule RemoveRule) error {
+	if rule < OrphanSubtasks || rule > RestrictSubtasks {
+		return Invalid(op, fmt.Errorf("unknown remove rule %d", rule))
+	}
+	return nil
+}
+
+// ValidateProject checks the rules every backend enforces before saving a
+// project.
+func ValidateProject(op string, p togo.Project) error {
+	if p.ID == uuid.Nil {
+		return Invalid(op, errors.New("project ID is required"))
+	}
+	if p.Name == "" {
+		return Invalid(op, errors.New("project name is required"))
+	}
+	if len(p.Name) > MaxNameLength {
+		return Invalid(op, fmt.Errorf("project name is longer than %d characters", MaxNameLength))
+	}
+	return nil
+}
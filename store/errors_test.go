@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/peschkaj/togo"
+	"strings"
+	"testing"
+)
+
+func TestErrorMatchesItsKind(t *testing.T) {
+	cause := errors.New("no rows in result set")
+	err := fmt.Errorf("wrapped: %w", NotFound("FindTaskByName", cause))
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("error does not match ErrNotFound")
+	}
+	if errors.Is(err, ErrConflict) || errors.Is(err, ErrInvalid) || errors.Is(err, ErrTimeout) {
+		t.Error("error matches the wrong kind")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("underlying cause is not reachable")
+	}
+
+	var storeErr *Error
+	if !errors.As(err, &storeErr) || storeErr.Op != "FindTaskByName" {
+		t.Error("unable to recover the store error")
+	}
+}
+
+func TestErrorKeepsContextErrors(t *testing.T) {
+	err := Conflict("AddOrUpdateTask", context.DeadlineExceeded)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("context error is not reachable")
+	}
+}
+
+func TestValidateTaskRequiresID(t *testing.T) {
+	task := togo.NewTask("name", "description")
+	task.ID = uuid.Nil
+
+	if err := ValidateTask("AddOrUpdateTask", task); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected %v found %v", ErrInvalid, err)
+	}
+}
+
+func TestValidateTask(t *testing.T) {
+	testCases := []struct {
+		name  string
+		valid bool
+	}{
+		{name: "", valid: false},
+		{name: "x", valid: true},
+		{name: strings.Repeat("x", MaxNameLength), valid: true},
+		{name: strings.Repeat("x", MaxNameLength+1), valid: false},
+	}
+
+	for _, testCase := range testCases {
+		err := ValidateTask("AddOrUpdateTask", togo.NewTask(testCase.name, ""))
+		if testCase.valid && err != nil {
+			t.Errorf("name of length %d: unexpected error %v", len(testCase.name), err)
+		}
+		if !testCase.valid && !errors.Is(err, ErrInvalid) {
+			t.Errorf("name of length %d: expected %v found %v", len(testCase.name), ErrInvalid, err)
+		}
+	}
+}
+
+func TestValidateProject(t *testing.T) {
+	valid := togo.NewProject("project", "")
+	if err := ValidateProject("AddOrUpdateProject", valid); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	missingID := valid
+	missingID.ID = uuid.Nil
+	missingName := valid
+	missingName.Name = ""
+	longName := valid
+	longName.Name = strings.Repeat("x", MaxNameLength+1)
+
+	for _, p := range []togo.Project{missingID, missingName, longName} {
+		if err := ValidateProject("AddOrUpdateProject", p); !errors.Is(err, ErrInvalid) {
+			t.Errorf("expected %v found %v", ErrInvalid, err)
+		}
+	}
+}
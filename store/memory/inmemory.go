@@ -1,19 +1,69 @@
 package memory
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
 	"github.com/peschkaj/togo"
+	"github.com/peschkaj/togo/store"
 	art "github.com/plar/go-adaptive-radix-tree"
 	"sort"
+	"sync"
 	"time"
 )
 
+var _ store.Store = (*InMemoryStore)(nil)
+
+// InMemoryStore keeps tasks in an ART keyed by task ID, with secondary
+// indexes holding the tasks for each name, due date, project and parent task.
+// Projects are kept in their own trees keyed by ID and by name.
+//
+// An InMemoryStore is safe for concurrent use. Readers share a read lock while
+// writers hold the write lock across every index they touch. The slices kept
+// in the secondary indexes are never modified once inserted; writers replace
+// them with updated copies instead.
 type InMemoryStore struct {
-	ts        art.Tree
-	byDueDate art.Tree
+	mu             sync.RWMutex
+	ts             art.Tree
+	ids            []uuid.UUID // task IDs in key order, for seeking to a cursor
+	byName         art.Tree
+	byDueDate      art.Tree
+	byProject      art.Tree
+	byParent       art.Tree
+	projects       art.Tree
+	projectsByName art.Tree
+	clock          togo.Clock
 }
 
-func NewMemoryStore() InMemoryStore {
-	return InMemoryStore{ts: art.New(), byDueDate: art.New()}
+// Option configures an InMemoryStore.
+type Option func(*InMemoryStore)
+
+// WithClock makes the store read the current time from clock instead of the
+// system clock.
+func WithClock(clock togo.Clock) Option {
+	return func(ms *InMemoryStore) {
+		ms.clock = clock
+	}
+}
+
+func NewMemoryStore(opts ...Option) *InMemoryStore {
+	ms := &InMemoryStore{
+		ts:             art.New(),
+		byName:         art.New(),
+		byDueDate:      art.New(),
+		byProject:      art.New(),
+		byParent:       art.New(),
+		projects:       art.New(),
+		projectsByName: art.New(),
+		clock:          togo.SystemClock,
+	}
+	for _, opt := range opts {
+		opt(ms)
+	}
+	return ms
 }
 
 func SortByPriority(ts togo.Tasks) []togo.Task {
@@ -26,58 +76,272 @@ func SortByPriority(ts togo.Tasks) []togo.Task {
 	return sorted
 }
 
-func (ms InMemoryStore) AddOrUpdateTask(t togo.Task) error {
-	ms.ts.Insert(art.Key(t.Name), t)
-	addOrUpdateByDueDate(ms.byDueDate, t)
+func (ms *InMemoryStore) AddOrUpdateTask(ctx context.Context, t togo.Task) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := store.ValidateTask("AddOrUpdateTask", t); err != nil {
+		return err
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if t.ProjectID != nil {
+		if _, found := ms.projects.Search(idToKey(*t.ProjectID)); !found {
+			return store.Invalid("AddOrUpdateTask", errors.New("task belongs to a project that does not exist"))
+		}
+	}
+	if err := ms.checkParent("AddOrUpdateTask", t.ID, t.ParentID); err != nil {
+		return err
+	}
+	if saved, found := ms.find(t.ID); found {
+		if saved.Status != t.Status {
+			return store.StatusChanged("AddOrUpdateTask", saved.Status, t.Status)
+		}
+		// the history only changes through transitions
+		t.Completed = saved.Completed
+		t.Transitions = saved.Transitions
+	}
+
+	ms.put(t)
 	return nil
 }
 
-func (ms InMemoryStore) RemoveTask(t togo.Task) error {
-	ms.ts.Delete(art.Key(t.Name))
-	removeByDueDate(ms.byDueDate, t)
+func (ms *InMemoryStore) RemoveTask(ctx context.Context, id uuid.UUID) error {
+	return ms.removeTask(ctx, "RemoveTask", id, store.OrphanSubtasks)
+}
+
+func (ms *InMemoryStore) RemoveTaskWith(ctx context.Context, id uuid.UUID, rule store.RemoveRule) error {
+	return ms.removeTask(ctx, "RemoveTaskWith", id, rule)
+}
+
+func (ms *InMemoryStore) removeTask(ctx context.Context, op string, id uuid.UUID, rule store.RemoveRule) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := store.ValidateRemoveRule(op, rule); err != nil {
+		return err
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	t, found := ms.find(id)
+	if !found {
+		return store.NotFound(op, nil)
+	}
+
+	subtasks := searchIndex(ms.byParent, idToKey(id))
+	switch rule {
+	case store.RestrictSubtasks:
+		if len(subtasks) > 0 {
+			return store.Conflict(op, fmt.Errorf("task has %d subtasks", len(subtasks)))
+		}
+	case store.RemoveSubtasks:
+		for _, descendant := range ms.descendants(id) {
+			ms.delete(descendant.ID)
+			ms.removeFromIndexes(descendant)
+		}
+	case store.OrphanSubtasks:
+		for _, subtask := range subtasks {
+			subtask.RemoveFromParent()
+			ms.put(subtask)
+		}
+	}
+
+	ms.delete(id)
+	ms.removeFromIndexes(t)
 	return nil
 }
 
-func (ms InMemoryStore) FindTaskByName(name string) (togo.Task, error) {
-	value, found := ms.ts.Search(art.Key(name))
+func (ms *InMemoryStore) Subtasks(ctx context.Context, id uuid.UUID) ([]togo.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	if _, found := ms.find(id); !found {
+		return nil, store.NotFound("Subtasks", nil)
+	}
+	return copyTasks(searchIndex(ms.byParent, idToKey(id))), nil
+}
+
+func (ms *InMemoryStore) MoveTask(ctx context.Context, id uuid.UUID, parent *uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	t, found := ms.find(id)
 	if !found {
-		return togo.Task{}, nil
+		return store.NotFound("MoveTask", nil)
+	}
+	if err := ms.checkParent("MoveTask", id, parent); err != nil {
+		return err
 	}
 
-	switch t := value.(type) {
-	case togo.Task:
-		return t, nil
-	default:
-		panic("type mismatch in index")
+	t.ParentID = parent
+	ms.put(t)
+	return nil
+}
+
+func (ms *InMemoryStore) CompleteTask(ctx context.Context, id uuid.UUID) (store.Completion, error) {
+	return ms.transition(ctx, "CompleteTask", id, togo.Completed)
+}
+
+func (ms *InMemoryStore) ReopenTask(ctx context.Context, id uuid.UUID) (togo.Task, error) {
+	completion, err := ms.transition(ctx, "ReopenTask", id, togo.Open)
+	return completion.Task, err
+}
+
+func (ms *InMemoryStore) TransitionTask(ctx context.Context, id uuid.UUID, to togo.Status) (store.Completion, error) {
+	return ms.transition(ctx, "TransitionTask", id, to)
+}
+
+// transition moves the task with the given ID to the status to and, when that
+// completes a recurring task, saves its next occurrence.
+func (ms *InMemoryStore) transition(ctx context.Context, op string, id uuid.UUID, to togo.Status) (store.Completion, error) {
+	if err := ctx.Err(); err != nil {
+		return store.Completion{}, err
+	}
+	if err := to.Validate(); err != nil {
+		return store.Completion{}, store.Invalid(op, err)
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	t, found := ms.find(id)
+	if !found {
+		return store.Completion{}, store.NotFound(op, nil)
+	}
+	if t.Status == to {
+		return store.Completion{Task: t}, nil
+	}
+
+	now := ms.clock.Now()
+	if err := t.TransitionBy(to, now, store.Actor(ctx)); err != nil {
+		return store.Completion{}, store.Conflict(op, err)
+	}
+	ms.put(t)
+	completion := store.Completion{Task: t}
+	if to != togo.Completed {
+		return completion, nil
+	}
+	if next, ok := store.NextOccurrence(t, now); ok {
+		ms.put(next)
+		completion.Next = &next
+	}
+	return completion, nil
+}
+
+// checkParent makes sure that parent, when set, exists and is neither the task
+// with the given ID nor one of its subtasks. The caller must hold the lock.
+func (ms *InMemoryStore) checkParent(op string, id uuid.UUID, parent *uuid.UUID) error {
+	if parent == nil {
+		return nil
+	}
+	if _, found := ms.find(*parent); !found {
+		return store.Invalid(op, errors.New("task's parent does not exist"))
 	}
+
+	for ancestor := parent; ancestor != nil; {
+		if *ancestor == id {
+			return store.Invalid(op, errors.New("a task can't be a subtask of itself or of its own subtasks"))
+		}
+		t, found := ms.find(*ancestor)
+		if !found {
+			break
+		}
+		ancestor = t.ParentID
+	}
+	return nil
+}
+
+// descendants returns every subtask of the task with the given ID, at any
+// depth. The caller must hold the lock.
+func (ms *InMemoryStore) descendants(id uuid.UUID) []togo.Task {
+	var tasks []togo.Task
+	for _, subtask := range searchIndex(ms.byParent, idToKey(id)) {
+		tasks = append(tasks, subtask)
+		tasks = append(tasks, ms.descendants(subtask.ID)...)
+	}
+	return tasks
 }
 
-func (ms InMemoryStore) FindByDueDate(dueDate *time.Time) ([]togo.Task, error) {
-	key := dateToKey(dueDate)
-	value, found := ms.byDueDate.Search(key)
+func (ms *InMemoryStore) FindTask(ctx context.Context, id uuid.UUID) (togo.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return togo.Task{}, err
+	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	t, found := ms.find(id)
 	if !found {
-		return nil, nil
+		return togo.Task{}, store.NotFound("FindTask", nil)
 	}
+	return t, nil
+}
 
-	switch tasks := value.(type) {
-	case []togo.Task:
-		return tasks, nil
-	default:
-		panic("type mismatch reading from index")
+func (ms *InMemoryStore) FindTasksByName(ctx context.Context, name string) ([]togo.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return copyTasks(searchIndex(ms.byName, art.Key(name))), nil
+}
+
+func (ms *InMemoryStore) FindByDueDate(ctx context.Context, dueDate *time.Time, loc *time.Location) ([]togo.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	if dueDate == nil {
+		return copyTasks(searchIndex(ms.byDueDate, dueToKey(nil))), nil
+	}
+	start, end := store.DaySpan(*dueDate, *dueDate, loc)
+	return ms.dueBetween(ctx, dueToKey(&start), dueToKey(&end))
 }
 
-func (ms InMemoryStore) Count() int {
-	return ms.ts.Size()
+func (ms *InMemoryStore) Count(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return ms.ts.Size(), nil
 }
 
-func (ms InMemoryStore) All() ([]togo.Task, error) {
+func (ms *InMemoryStore) All(ctx context.Context) ([]togo.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
 	items := []togo.Task{}
 
 	iter := ms.ts.Iterator()
 
 	for iter.HasNext() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		node, err := iter.Next()
 		if err != nil {
 			return nil, err
@@ -94,56 +358,449 @@ func (ms InMemoryStore) All() ([]togo.Task, error) {
 	return items, nil
 }
 
-func (ms InMemoryStore) OverdueTasks() ([]togo.Task, error) {
+// ListTasks seeks to the cursor in the sorted ID slice, so each page costs
+// the size of the page rather than the number of tasks before it.
+func (ms *InMemoryStore) ListTasks(ctx context.Context, page store.Page) (store.TaskPage, error) {
+	if err := ctx.Err(); err != nil {
+		return store.TaskPage{}, err
+	}
+	after, err := store.DecodeCursor("ListTasks", page.Cursor)
+	if err != nil {
+		return store.TaskPage{}, err
+	}
+	size := page.Size()
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	start := 0
+	if after != uuid.Nil {
+		start = sort.Search(len(ms.ids), func(i int) bool {
+			return compareIDs(ms.ids[i], after) > 0
+		})
+	}
+	ids := ms.ids[start:]
+	if len(ids) > size {
+		ids = ids[:size]
+	}
+
+	result := store.TaskPage{Tasks: make([]togo.Task, 0, len(ids))}
+	for _, id := range ids {
+		t, _ := ms.find(id)
+		result.Tasks = append(result.Tasks, t)
+	}
+	if start+len(ids) < len(ms.ids) {
+		result.Next = store.EncodeCursor(ids[len(ids)-1])
+	}
+
+	return result, nil
+}
+
+// QueryTasks reads the candidates from the narrowest index the query covers
+// and filters them with the rest of the query.
+func (ms *InMemoryStore) QueryTasks(ctx context.Context, q store.Query) ([]togo.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := store.ValidateQuery("QueryTasks", q); err != nil {
+		return nil, err
+	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	candidates, err := ms.candidates(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []togo.Task{}
+	for _, t := range candidates {
+		if q.Matches(t) {
+			tasks = append(tasks, t)
+		}
+	}
+	q.SortTasks(tasks)
+	return tasks, nil
+}
+
+// candidates returns a superset of the tasks matching q: the project's
+// tasks, the tasks in the due date buckets in range, or every task. The
+// caller must hold the read lock.
+func (ms *InMemoryStore) candidates(ctx context.Context, q store.Query) ([]togo.Task, error) {
+	switch {
+	case q.ProjectID != nil:
+		return searchIndex(ms.byProject, idToKey(*q.ProjectID)), nil
+	case q.DueFrom != nil || q.DueTo != nil:
+		startKey, endKey := art.Key{datedKey}, art.Key{datedKey + 1}
+		start, end := q.DueSpan()
+		if start != nil {
+			startKey = dueToKey(start)
+		}
+		if end != nil {
+			endKey = dueToKey(end)
+		}
+		return ms.dueBetween(ctx, startKey, endKey)
+	}
+
+	tasks := make([]togo.Task, 0, ms.ts.Size())
+	iter := ms.ts.Iterator()
+	for iter.HasNext() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		node, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, node.Value().(togo.Task))
+	}
+	return tasks, nil
+}
+
+func (ms *InMemoryStore) FindDueBetween(ctx context.Context, start, end time.Time, loc *time.Location) ([]togo.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	spanStart, spanEnd := store.DaySpan(start, end, loc)
+	return ms.dueBetween(ctx, dueToKey(&spanStart), dueToKey(&spanEnd))
+}
+
+func (ms *InMemoryStore) OverdueTasks(ctx context.Context, loc *time.Location) ([]togo.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	now := ms.clock.Now().UTC()
+	if loc != nil {
+		now = now.In(loc)
+	}
+	candidates, err := ms.dueBetween(ctx, art.Key{datedKey}, dueToKey(&now))
+	if err != nil {
+		return nil, err
+	}
+
+	// tasks due today without a time aren't overdue until the day is over
+	tasks := candidates[:0]
+	for _, t := range candidates {
+		if t.OverdueAt(now) {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks, nil
+}
+
+func (ms *InMemoryStore) Upcoming(ctx context.Context, window store.UpcomingWindow) ([]togo.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	start, end := window.Span(ms.clock.Now())
+	candidates, err := ms.dueBetween(ctx, dueToKey(&start), dueToKey(&end))
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := candidates[:0]
+	for _, t := range candidates {
+		if window.IncludeCompleted || !t.Status.IsFinished() {
+			tasks = append(tasks, t)
+		}
+	}
+
+	togo.Tasks(tasks).SortBy(togo.ByDueDate, togo.Reverse(togo.ByPriority))
+	return tasks, nil
+}
+
+// dueBetween returns the tasks in every due date bucket with a key from start
+// up to, but not including, end, in due date order. The caller must hold the
+// read lock.
+func (ms *InMemoryStore) dueBetween(ctx context.Context, start, end art.Key) ([]togo.Task, error) {
 	iter := ms.byDueDate.Iterator()
-	var tasks []togo.Task
-	now := time.Now()
+	tasks := []togo.Task{}
 
-loop:
 	for iter.HasNext() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		node, err := iter.Next()
 		if err != nil {
-			return tasks, nil
+			return nil, err
+		}
+
+		key := node.Key()
+		if bytes.Compare(key, start) < 0 {
+			continue
+		}
+		// keys are visited in order, nothing later can be in range
+		if bytes.Compare(key, end) >= 0 {
+			break
 		}
 
 		switch nodeTasks := node.Value().(type) {
 		case []togo.Task:
-			if len(nodeTasks) == 0 {
-				continue
-			}
+			tasks = append(tasks, nodeTasks...)
+		default:
+			panic("type mismatch reading from index")
+		}
+	}
 
-			if nodeTasks[0].DueOn() == nil {
-				continue
-			}
+	return tasks, nil
+}
 
-			if nodeTasks[0].DueOn().After(now) {
-				break loop
-			}
-			tasks = append(tasks, nodeTasks[:]...)
+func (ms *InMemoryStore) AddOrUpdateProject(ctx context.Context, p togo.Project) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := store.ValidateProject("AddOrUpdateProject", p); err != nil {
+		return err
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if other, found := ms.projectsByName.Search(art.Key(p.Name)); found && other.(togo.Project).ID != p.ID {
+		return store.Conflict("AddOrUpdateProject", fmt.Errorf("project %q already exists", p.Name))
+	}
+
+	if previous, found := ms.projects.Search(idToKey(p.ID)); found {
+		ms.projectsByName.Delete(art.Key(previous.(togo.Project).Name))
+	}
+	ms.projects.Insert(idToKey(p.ID), p)
+	ms.projectsByName.Insert(art.Key(p.Name), p)
+	return nil
+}
+
+func (ms *InMemoryStore) RemoveProject(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	value, deleted := ms.projects.Delete(idToKey(id))
+	if !deleted {
+		return store.NotFound("RemoveProject", nil)
+	}
+	ms.projectsByName.Delete(art.Key(value.(togo.Project).Name))
+
+	// the project's tasks are kept, they just no longer belong to it
+	for _, t := range searchIndex(ms.byProject, idToKey(id)) {
+		t.RemoveFromProject()
+		ms.put(t)
+	}
+	return nil
+}
+
+func (ms *InMemoryStore) FindProject(ctx context.Context, id uuid.UUID) (togo.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return togo.Project{}, err
+	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	value, found := ms.projects.Search(idToKey(id))
+	if !found {
+		return togo.Project{}, store.NotFound("FindProject", nil)
+	}
+	return value.(togo.Project), nil
+}
+
+func (ms *InMemoryStore) FindProjectByName(ctx context.Context, name string) (togo.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return togo.Project{}, err
+	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	value, found := ms.projectsByName.Search(art.Key(name))
+	if !found {
+		return togo.Project{}, store.NotFound("FindProjectByName", nil)
+	}
+	return value.(togo.Project), nil
+}
+
+// AllProjects returns every project ordered by name.
+func (ms *InMemoryStore) AllProjects(ctx context.Context) ([]togo.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	projects := []togo.Project{}
+	iter := ms.projectsByName.Iterator()
+	for iter.HasNext() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
 		}
+
+		node, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, node.Value().(togo.Project))
 	}
+	return projects, nil
+}
 
-	return tasks, nil
+func (ms *InMemoryStore) TasksInProject(ctx context.Context, id uuid.UUID) ([]togo.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	if _, found := ms.projects.Search(idToKey(id)); !found {
+		return nil, store.NotFound("TasksInProject", nil)
+	}
+	return copyTasks(searchIndex(ms.byProject, idToKey(id))), nil
+}
+
+// put saves t and indexes it. The caller must hold the write lock.
+func (ms *InMemoryStore) put(t togo.Task) {
+	// the name, due date or project may have changed, drop the previous
+	// version from the secondary indexes before adding the new one
+	if previous, found := ms.find(t.ID); found {
+		ms.removeFromIndexes(previous)
+	} else {
+		i := ms.searchIDs(t.ID)
+		ms.ids = append(ms.ids, uuid.Nil)
+		copy(ms.ids[i+1:], ms.ids[i:])
+		ms.ids[i] = t.ID
+	}
+
+	ms.ts.Insert(idToKey(t.ID), t)
+	updateIndex(ms.byName, art.Key(t.Name), t)
+	addOrUpdateByDueDate(ms.byDueDate, t)
+	if t.ProjectID != nil {
+		updateIndex(ms.byProject, idToKey(*t.ProjectID), t)
+	}
+	if t.ParentID != nil {
+		updateIndex(ms.byParent, idToKey(*t.ParentID), t)
+	}
+}
+
+// delete removes the task with id from the primary index and the ID slice,
+// leaving the secondary indexes to the caller. The caller must hold the write
+// lock.
+func (ms *InMemoryStore) delete(id uuid.UUID) {
+	ms.ts.Delete(idToKey(id))
+	if i := ms.searchIDs(id); i < len(ms.ids) && ms.ids[i] == id {
+		ms.ids = append(ms.ids[:i], ms.ids[i+1:]...)
+	}
+}
+
+// searchIDs returns the position of id in the sorted ID slice, or where it
+// would be inserted.
+func (ms *InMemoryStore) searchIDs(id uuid.UUID) int {
+	return sort.Search(len(ms.ids), func(i int) bool {
+		return compareIDs(ms.ids[i], id) >= 0
+	})
+}
+
+// compareIDs orders IDs the way idToKey orders their keys: the bytes of a
+// UUID sort the same as its canonical hex string.
+func compareIDs(a, b uuid.UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+func (ms *InMemoryStore) find(id uuid.UUID) (togo.Task, bool) {
+	value, found := ms.ts.Search(idToKey(id))
+	if !found {
+		return togo.Task{}, false
+	}
+
+	switch t := value.(type) {
+	case togo.Task:
+		return t, true
+	default:
+		panic("type mismatch in index")
+	}
+}
+
+func (ms *InMemoryStore) removeFromIndexes(t togo.Task) {
+	removeFromIndex(ms.byName, art.Key(t.Name), t)
+	removeByDueDate(ms.byDueDate, t)
+	if t.ProjectID != nil {
+		removeFromIndex(ms.byProject, idToKey(*t.ProjectID), t)
+	}
+	if t.ParentID != nil {
+		removeFromIndex(ms.byParent, idToKey(*t.ParentID), t)
+	}
+}
+
+func idToKey(id uuid.UUID) art.Key {
+	return art.Key(id.String())
+}
+
+func searchIndex(tree art.Tree, key art.Key) []togo.Task {
+	value, found := tree.Search(key)
+	if !found {
+		return nil
+	}
+
+	switch tasks := value.(type) {
+	case []togo.Task:
+		return tasks
+	default:
+		panic("type mismatch reading from index")
+	}
+}
+
+// copyTasks keeps callers from modifying the slices held by an index.
+func copyTasks(tasks []togo.Task) []togo.Task {
+	if tasks == nil {
+		return nil
+	}
+	return append([]togo.Task(nil), tasks...)
 }
 
 func addOrUpdateByDueDate(tree art.Tree, t togo.Task) {
-	key := dateToKey(t.DueOn())
+	key := dueToKey(t.DueOn())
 	updateIndex(tree, key, t)
 }
 
 func removeByDueDate(tree art.Tree, t togo.Task) bool {
-	key := dateToKey(t.DueOn())
+	key := dueToKey(t.DueOn())
 
 	return removeFromIndex(tree, key, t)
 }
 
-func dateToKey(date *time.Time) art.Key {
-	var key art.Key = []byte{}
-	if date != nil {
-		yyyy, mm, dd := date.Date()
-		newDate := time.Date(yyyy, mm, dd, 0, 0, 0, 0, time.UTC)
-		key, _ = newDate.GobEncode()
+// Due date keys start with a marker byte so that tasks without a due date sort
+// before, and are never a prefix of, the dated keys.
+const (
+	undatedKey byte = iota
+	datedKey
+)
+
+// dueToKey encodes the moment of due so that keys sort chronologically
+// whatever the due date's time zone: the seconds since the Unix epoch,
+// big-endian with the sign bit flipped so moments before 1970 sort before
+// moments after it, followed by the nanoseconds.
+func dueToKey(due *time.Time) art.Key {
+	if due == nil {
+		return art.Key{undatedKey}
 	}
+
+	key := make(art.Key, 13)
+	key[0] = datedKey
+	binary.BigEndian.PutUint64(key[1:], uint64(due.Unix())^(1<<63))
+	binary.BigEndian.PutUint32(key[9:], uint32(due.Nanosecond()))
 	return key
 }
 
@@ -156,32 +813,27 @@ func removeFromIndex(tree art.Tree, key art.Key, t togo.Task) bool {
 
 	switch tasks := value.(type) {
 	case []togo.Task:
-		originalLength := len(tasks)
-		// delete an element from the array
-		newLength := 0
-		for i := range tasks {
-			if t.Name != tasks[i].Name {
-				tasks[newLength] = tasks[i]
-				newLength++
+		// copy the remaining tasks, readers may still hold the old slice
+		remaining := make([]togo.Task, 0, len(tasks))
+		for _, task := range tasks {
+			if t.ID != task.ID {
+				remaining = append(remaining, task)
 			}
 		}
 
-		// re-slice the array to remove extra index
-		tasks = tasks[:newLength]
-
 		// didn't find it
-		if newLength == originalLength {
+		if len(remaining) == len(tasks) {
 			return false
 		}
 
 		// this was the last item for this index, remove it
-		if newLength == 0 {
+		if len(remaining) == 0 {
 			tree.Delete(key)
 			return true
 		}
 
 		// wasn't the last item, need to update the indexed values
-		tree.Insert(key, tasks)
+		tree.Insert(key, remaining)
 		return true
 	}
 
@@ -196,22 +848,25 @@ func updateIndex(tree art.Tree, key art.Key, t togo.Task) {
 		return
 	}
 
-	// update by searching for the task by name
+	// update by searching for the task by ID, building a new list because
+	// readers may still hold the old one
 	switch tasks := value.(type) {
 	case []togo.Task:
-		for i, task := range tasks {
-			if task.Name == t.Name {
-				// found it, update the list in place
-				tasks[i] = t
-				// save back to the tree and bail
-				_, _ = tree.Insert(key, tasks)
-				return
+		updated := make([]togo.Task, 0, len(tasks)+1)
+		replaced := false
+		for _, task := range tasks {
+			if task.ID == t.ID {
+				task = t
+				replaced = true
 			}
+			updated = append(updated, task)
 		}
 
 		// didn't find the task, eh?
-		tasks = append(tasks, t)
-		tree.Insert(key, tasks)
+		if !replaced {
+			updated = append(updated, t)
+		}
+		tree.Insert(key, updated)
 		return
 	default:
 		panic("type mismatch in index")
@@ -1,18 +1,27 @@
 package memory
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/jaswdr/faker"
 	"github.com/peschkaj/togo"
+	"github.com/peschkaj/togo/store"
+	"github.com/peschkaj/togo/store/storetest"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 )
 
 func TestMultipleCallsToAddIncreaseCount(t *testing.T) {
 	ms := NewMemoryStore()
+	ctx := context.Background()
 	f := faker.New()
 
-	initialCount := ms.Count()
+	initialCount, _ := ms.Count(ctx)
 	if initialCount != 0 {
 		t.Error("memory store is not empty after initialization")
 	}
@@ -20,8 +29,8 @@ func TestMultipleCallsToAddIncreaseCount(t *testing.T) {
 	var previousCount = initialCount
 
 	for i := 0; i < 3; i++ {
-		ms.AddOrUpdateTask(togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(3)))
-		count := ms.Count()
+		ms.AddOrUpdateTask(ctx, togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(3)))
+		count, _ := ms.Count(ctx)
 
 		if count <= previousCount {
 			t.Error("count did not increment after AddOrUpdateTask()")
@@ -31,16 +40,17 @@ func TestMultipleCallsToAddIncreaseCount(t *testing.T) {
 
 func TestUpdatedTaskIsChanged(t *testing.T) {
 	ms := NewMemoryStore()
+	ctx := context.Background()
 	f := faker.New()
 
 	task := togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(1))
-	_ = ms.AddOrUpdateTask(task)
+	_ = ms.AddOrUpdateTask(ctx, task)
 	originalTask := task
 
 	task.Description = f.Lorem().Paragraph(3)
-	_ = ms.AddOrUpdateTask(task)
+	_ = ms.AddOrUpdateTask(ctx, task)
 
-	updatedTask, err := ms.FindTaskByName(task.Name)
+	updatedTask, err := ms.FindTask(ctx, task.ID)
 	if err != nil {
 		t.Error("task saved but not err after update")
 	}
@@ -50,71 +60,130 @@ func TestUpdatedTaskIsChanged(t *testing.T) {
 	}
 }
 
-func TestTasksCanBeRetrievedByName(t *testing.T) {
+func TestTasksCanBeRetrievedByID(t *testing.T) {
 	ms := NewMemoryStore()
+	ctx := context.Background()
 	f := faker.New()
 
 	task := togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(1))
 
 	// add the task we want to find
-	_ = ms.AddOrUpdateTask(task)
+	_ = ms.AddOrUpdateTask(ctx, task)
 	// and several other tasks to ensure we're not testing a degenerate case
 	for i := 0; i < 3; i++ {
-		_ = ms.AddOrUpdateTask(togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(3)))
+		_ = ms.AddOrUpdateTask(ctx, togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(3)))
 	}
 
-	otherTask, err := ms.FindTaskByName(task.Name)
+	otherTask, err := ms.FindTask(ctx, task.ID)
 	if err != nil {
-		t.Error("unable to find task by name")
+		t.Error("unable to find task by ID")
 	}
 
-	if otherTask != task {
+	if !reflect.DeepEqual(otherTask, task) {
 		t.Error("found task wasn't the same as original task")
 	}
 }
 
-func TestFindByNameReturnsFalseWhenNotFound(t *testing.T) {
+func TestFindByIDReturnsNotFound(t *testing.T) {
 	ms := NewMemoryStore()
+	ctx := context.Background()
 	f := faker.New()
 
 	// add several tasks to ensure we're not testing a degenerate case
 	for i := 0; i < 3; i++ {
-		_ = ms.AddOrUpdateTask(togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(3)))
+		_ = ms.AddOrUpdateTask(ctx, togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(3)))
 	}
 
-	_, err := ms.FindTaskByName("asdf")
+	_, err := ms.FindTask(ctx, uuid.New())
 
-	if err != nil {
+	if !errors.Is(err, store.ErrNotFound) {
 		t.Error("searched for a task that does not exist and found it")
 	}
 }
 
+func TestRenamedTaskIsReindexed(t *testing.T) {
+	ms := NewMemoryStore()
+	ctx := context.Background()
+	f := faker.New()
+
+	task := togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(1))
+	originalName := task.Name
+	_ = ms.AddOrUpdateTask(ctx, task)
+
+	task.Name = f.Person().Name() + " renamed"
+	_ = ms.AddOrUpdateTask(ctx, task)
+
+	if count, _ := ms.Count(ctx); count != 1 {
+		t.Errorf("renaming created a duplicate, expected %d tasks found %d", 1, count)
+	}
+
+	if tasks, _ := ms.FindTasksByName(ctx, originalName); len(tasks) != 0 {
+		t.Error("task can still be found by its original name")
+	}
+
+	tasks, _ := ms.FindTasksByName(ctx, task.Name)
+	if len(tasks) != 1 || tasks[0].ID != task.ID {
+		t.Error("task cannot be found by its new name")
+	}
+}
+
 func TestRemovedTaskCannotBeFound(t *testing.T) {
 	ms := NewMemoryStore()
+	ctx := context.Background()
 	f := faker.New()
-	originalCount := ms.Count()
+	originalCount, _ := ms.Count(ctx)
 
 	task := togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(1))
-	_ = ms.AddOrUpdateTask(task)
+	_ = ms.AddOrUpdateTask(ctx, task)
 	for i := 0; i < 3; i++ {
-		_ = ms.AddOrUpdateTask(togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(3)))
+		_ = ms.AddOrUpdateTask(ctx, togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(3)))
 	}
 
-	if !(ms.Count() > originalCount) {
+	if count, _ := ms.Count(ctx); !(count > originalCount) {
 		t.Error("current count is not greater than original count")
 	}
 
-	if err := ms.RemoveTask(task); err != nil {
+	if err := ms.RemoveTask(ctx, task.ID); err != nil {
 		t.Error("unable to remove task")
 	}
 
-	if _, err := ms.FindTaskByName(task.Name); err != nil {
+	if _, err := ms.FindTask(ctx, task.ID); !errors.Is(err, store.ErrNotFound) {
 		t.Error("found task in store but should not be able to")
 	}
 }
 
+func TestSortedIDsFollowTheTaskIndex(t *testing.T) {
+	ms := NewMemoryStore()
+	ctx := context.Background()
+	f := faker.New()
+
+	var tasks []togo.Task
+	for i := 0; i < 8; i++ {
+		task := togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(1))
+		_ = ms.AddOrUpdateTask(ctx, task)
+		tasks = append(tasks, task)
+	}
+	tasks[2].Name = "renamed"
+	_ = ms.AddOrUpdateTask(ctx, tasks[2])
+	_ = ms.RemoveTask(ctx, tasks[5].ID)
+
+	var keys []string
+	for iter := ms.ts.Iterator(); iter.HasNext(); {
+		node, _ := iter.Next()
+		keys = append(keys, string(node.Key()))
+	}
+	var ids []string
+	for _, id := range ms.ids {
+		ids = append(ids, string(idToKey(id)))
+	}
+	if !reflect.DeepEqual(ids, keys) {
+		t.Errorf("expected IDs %v found %v", keys, ids)
+	}
+}
+
 func TestOverdueTasksCanBeRetrieved(t *testing.T) {
 	ms := NewMemoryStore()
+	ctx := context.Background()
 	f := faker.New()
 	// start with two days ago
 	start := -2
@@ -124,13 +193,13 @@ func TestOverdueTasksCanBeRetrieved(t *testing.T) {
 		task := togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(3))
 		durationString := fmt.Sprintf("%dh", start*24)
 		duration, _ := time.ParseDuration(durationString)
-		dueDate := time.Now().Add(time.Hour * duration)
+		dueDate := time.Now().UTC().Add(time.Hour * duration)
 		task.AddDueDate(dueDate)
 
-		ms.AddOrUpdateTask(task)
+		ms.AddOrUpdateTask(ctx, task)
 	}
 
-	overdueTasks, err := ms.OverdueTasks()
+	overdueTasks, err := ms.OverdueTasks(ctx, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -145,11 +214,12 @@ func TestOverdueTasksCanBeRetrieved(t *testing.T) {
 
 func TestTasksCanBeRetrievedByDueDate(t *testing.T) {
 	ms := NewMemoryStore()
+	ctx := context.Background()
 	f := faker.New()
 	// start with two days ago
 	start := -2
 
-	now := time.Now()
+	now := time.Now().UTC()
 
 	for i := 0; i < 10; i++ {
 		start += i
@@ -162,10 +232,10 @@ func TestTasksCanBeRetrievedByDueDate(t *testing.T) {
 		dueDate := now.Add(time.Hour * duration)
 		task.AddDueDate(dueDate)
 
-		_ = ms.AddOrUpdateTask(task)
+		_ = ms.AddOrUpdateTask(ctx, task)
 	}
 
-	tasks, err := ms.FindByDueDate(&now)
+	tasks, err := ms.FindByDueDate(ctx, &now, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -180,12 +250,13 @@ func TestTasksCanBeRetrievedByDueDate(t *testing.T) {
 
 func TestTasksCanBeRetrievedByNilDueDate(t *testing.T) {
 	ms := NewMemoryStore()
+	ctx := context.Background()
 	f := faker.New()
 
 	task := togo.NewTask(f.Person().Name(), f.Lorem().Paragraph(1))
-	_ = ms.AddOrUpdateTask(task)
+	_ = ms.AddOrUpdateTask(ctx, task)
 
-	tasks, err := ms.FindByDueDate(nil)
+	tasks, err := ms.FindByDueDate(ctx, nil, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -197,3 +268,161 @@ func TestTasksCanBeRetrievedByNilDueDate(t *testing.T) {
 		t.Error("incorrect number of tasks found with nil due date")
 	}
 }
+
+func TestInMemoryStoreConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T, clock togo.Clock) store.Store {
+		return NewMemoryStore(WithClock(clock))
+	})
+}
+
+// TestConcurrentAccess is meant to be run with -race. Writers add, update and
+// remove tasks while readers query every index, then the indexes are checked
+// for consistency.
+func TestConcurrentAccess(t *testing.T) {
+	ms := NewMemoryStore()
+	ctx := context.Background()
+
+	const writers, readers, iterations = 8, 8, 200
+	today := time.Now().UTC()
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				task := togo.NewTask(fmt.Sprintf("writer %d", w), fmt.Sprintf("task %d", i))
+				task.AddDueDate(today.Add(time.Duration(i%5-2) * 24 * time.Hour))
+				if err := ms.AddOrUpdateTask(ctx, task); err != nil {
+					t.Error(err)
+					return
+				}
+
+				// move the task to another due date bucket
+				task.AddDueDate(today.Add(time.Duration(i%3) * 24 * time.Hour))
+				if err := ms.AddOrUpdateTask(ctx, task); err != nil {
+					t.Error(err)
+					return
+				}
+
+				if i%2 == 0 {
+					if err := ms.RemoveTask(ctx, task.ID); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}
+		}(w)
+	}
+
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func(r int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				var err error
+				switch i % 5 {
+				case 0:
+					_, err = ms.All(ctx)
+				case 1:
+					_, err = ms.OverdueTasks(ctx, nil)
+				case 2:
+					var tasks []togo.Task
+					tasks, err = ms.FindTasksByName(ctx, fmt.Sprintf("writer %d", r%writers))
+					// callers may sort the results without affecting the store
+					SortByPriority(tasks)
+				case 3:
+					_, err = ms.FindByDueDate(ctx, &today, nil)
+				case 4:
+					_, err = ms.Count(ctx)
+				}
+				if err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(r)
+	}
+	wg.Wait()
+
+	all, err := ms.All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := writers * iterations / 2; len(all) != expected {
+		t.Fatalf("expected %d tasks found %d", expected, len(all))
+	}
+
+	for _, task := range all {
+		byDate, _ := ms.FindByDueDate(ctx, task.DueOn(), nil)
+		if !containsTask(byDate, task) {
+			t.Fatalf("task %s is missing from the due date index", task.ID)
+		}
+		byName, _ := ms.FindTasksByName(ctx, task.Name)
+		if !containsTask(byName, task) {
+			t.Fatalf("task %s is missing from the name index", task.ID)
+		}
+	}
+
+	indexed := 0
+	for w := 0; w < writers; w++ {
+		byName, _ := ms.FindTasksByName(ctx, fmt.Sprintf("writer %d", w))
+		indexed += len(byName)
+	}
+	if indexed != len(all) {
+		t.Errorf("name index holds %d tasks, expected %d", indexed, len(all))
+	}
+}
+
+func containsTask(tasks []togo.Task, t togo.Task) bool {
+	for _, task := range tasks {
+		if reflect.DeepEqual(task, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDueKeysSortChronologically(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dates := []time.Time{
+		time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(1969, 12, 31, 23, 59, 59, 999, time.UTC),
+		time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1970, 1, 1, 0, 0, 0, 1, time.UTC),
+		time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC),
+		// midnight in Tokyo is still the day before in UTC
+		time.Date(2023, 1, 1, 0, 0, 0, 0, tokyo),
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2300, 6, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	previous := dueToKey(nil)
+	for _, date := range dates {
+		key := dueToKey(&date)
+		if bytes.Compare(previous, key) >= 0 {
+			t.Errorf("key for %s does not sort after the previous key", date)
+		}
+		previous = key
+	}
+}
+
+func TestDueKeysIgnoreTimeZone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utc := time.Date(2023, 3, 14, 15, 0, 0, 0, time.UTC)
+	inTokyo := utc.In(tokyo)
+
+	if !bytes.Equal(dueToKey(&utc), dueToKey(&inTokyo)) {
+		t.Error("the same moment in two time zones has different keys")
+	}
+}
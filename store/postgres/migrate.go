@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the key of the advisory lock held while migrating so
+// that several processes starting at once don't apply the same migration.
+const migrationLockID int64 = 0x746f676f // "togo"
+
+const createSchemaVersion = `-- name: CreateSchemaVersion
+CREATE SCHEMA IF NOT EXISTS togo;
+CREATE TABLE IF NOT EXISTS togo.schema_version (
+    version INT PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    applied_on TIMESTAMPTZ(6) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`
+
+const schemaVersionExists = `-- name: SchemaVersionExists
+SELECT to_regclass('togo.schema_version') IS NOT NULL;
+`
+
+const currentSchemaVersion = `-- name: CurrentSchemaVersion
+SELECT COALESCE(MAX(version), 0) FROM togo.schema_version;
+`
+
+const recordMigration = `-- name: RecordMigration
+INSERT INTO togo.schema_version (version, name) VALUES ($1, $2);
+`
+
+const forgetMigration = `-- name: ForgetMigration
+DELETE FROM togo.schema_version WHERE version = $1;
+`
+
+type migration struct {
+	version int
+	name    string
+	up      string
+	down    string
+}
+
+// loadMigrations reads the embedded migrations, named NNNN_name.up.sql and
+// NNNN_name.down.sql, and returns them ordered by version.
+func loadMigrations() ([]migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*migration{}
+	for _, entry := range entries {
+		fileName := entry.Name()
+		base := strings.TrimSuffix(fileName, ".sql")
+		direction := path.Ext(base)
+		base = strings.TrimSuffix(base, direction)
+
+		prefix, name, found := strings.Cut(base, "_")
+		version, err := strconv.Atoi(prefix)
+		if !found || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s does not start with a version number", fileName)
+		}
+
+		contents, err := fs.ReadFile(migrationFiles, path.Join("migrations", fileName))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{version: version, name: name}
+			byVersion[version] = m
+		}
+		if m.name != name {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, m.name, name)
+		}
+
+		switch direction {
+		case ".up":
+			m.up = string(contents)
+		case ".down":
+			m.down = string(contents)
+		default:
+			return nil, fmt.Errorf("migration %s is neither .up.sql nor .down.sql", fileName)
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" || m.down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down script", m.version, m.name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+
+	for i, m := range migrations {
+		if m.version != i+1 {
+			return nil, fmt.Errorf("migration %d is missing", i+1)
+		}
+	}
+	return migrations, nil
+}
+
+// LatestSchemaVersion is the schema version this build of togo expects.
+func LatestSchemaVersion() (int, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return 0, err
+	}
+	return len(migrations), nil
+}
+
+// SchemaVersion reports the version of the most recently applied migration,
+// 0 for a database that has never been migrated.
+func (p *PgStore) SchemaVersion(ctx context.Context) (int, error) {
+	var exists bool
+	if err := p.pool.QueryRow(ctx, schemaVersionExists).Scan(&exists); err != nil || !exists {
+		return 0, err
+	}
+
+	var version int
+	err := p.pool.QueryRow(ctx, currentSchemaVersion).Scan(&version)
+	return version, err
+}
+
+// Migrate applies every pending migration.
+func (p *PgStore) Migrate(ctx context.Context) error {
+	latest, err := LatestSchemaVersion()
+	if err != nil {
+		return err
+	}
+	return p.MigrateTo(ctx, latest)
+}
+
+// MigrateTo applies or reverts migrations until the schema is at version.
+// Each migration runs in its own transaction together with the update to
+// togo.schema_version, and an advisory lock serializes concurrent migrators.
+func (p *PgStore) MigrateTo(ctx context.Context, version int) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+	if version < 0 || version > len(migrations) {
+		return fmt.Errorf("unknown schema version %d, latest is %d", version, len(migrations))
+	}
+
+	conn, err := p.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1);", migrationLockID); err != nil {
+		return err
+	}
+	defer func() {
+		// unlock even when ctx is done so the session doesn't keep the lock
+		_, _ = conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1);", migrationLockID)
+	}()
+
+	if _, err := conn.Exec(ctx, createSchemaVersion); err != nil {
+		return err
+	}
+
+	var current int
+	if err := conn.QueryRow(ctx, currentSchemaVersion).Scan(&current); err != nil {
+		return err
+	}
+	if err := checkSchemaVersion(current, len(migrations)); err != nil {
+		return err
+	}
+
+	for current < version {
+		m := migrations[current]
+		if err := applyMigration(ctx, conn.Conn(), m.up, recordMigration, m.version, m.name); err != nil {
+			return fmt.Errorf("migrating up to %d_%s: %w", m.version, m.name, err)
+		}
+		current++
+	}
+
+	for current > version {
+		m := migrations[current-1]
+		if err := applyMigration(ctx, conn.Conn(), m.down, forgetMigration, m.version); err != nil {
+			return fmt.Errorf("migrating down from %d_%s: %w", m.version, m.name, err)
+		}
+		current--
+	}
+
+	return nil
+}
+
+// checkSchemaVersion refuses a database migrated by a newer build: the
+// migrations needed to revert it aren't embedded in this one.
+func checkSchemaVersion(current, latest int) error {
+	if current > latest {
+		return fmt.Errorf("schema version %d is newer than this build's latest %d", current, latest)
+	}
+	return nil
+}
+
+func applyMigration(ctx context.Context, conn *pgx.Conn, script, bookkeeping string, args ...any) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, script); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, bookkeeping, args...)
+		return err
+	})
+}
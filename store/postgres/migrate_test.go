@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMigrationsAreComplete(t *testing.T) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(migrations) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+
+	for i, m := range migrations {
+		if m.version != i+1 {
+			t.Errorf("expected migration %d found %d", i+1, m.version)
+		}
+		if strings.TrimSpace(m.up) == "" || strings.TrimSpace(m.down) == "" {
+			t.Errorf("migration %d_%s is empty", m.version, m.name)
+		}
+	}
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	pg := newTestStore(t)
+	ctx := context.Background()
+
+	latest, err := LatestSchemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := pg.Migrate(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		version, err := pg.SchemaVersion(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version != latest {
+			t.Errorf("expected schema version %d found %d", latest, version)
+		}
+	}
+}
+
+func TestConcurrentMigrationsDoNotConflict(t *testing.T) {
+	pg := newTestStore(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- pg.Migrate(ctx)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestMigrateToRejectsUnknownVersions(t *testing.T) {
+	pg := newTestStore(t)
+	ctx := context.Background()
+
+	latest, err := LatestSchemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, version := range []int{-1, latest + 1} {
+		if err := pg.MigrateTo(ctx, version); err == nil {
+			t.Errorf("migrated to unknown version %d", version)
+		}
+	}
+}
+
+func TestNewerSchemaVersionsAreRejected(t *testing.T) {
+	for _, tc := range []struct {
+		current, latest int
+		ok              bool
+	}{
+		{current: 0, latest: 9, ok: true},
+		{current: 9, latest: 9, ok: true},
+		{current: 10, latest: 9, ok: false},
+	} {
+		err := checkSchemaVersion(tc.current, tc.latest)
+		if (err == nil) != tc.ok {
+			t.Errorf("schema version %d with latest %d: unexpected error %v", tc.current, tc.latest, err)
+		}
+	}
+}
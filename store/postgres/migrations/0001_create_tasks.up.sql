@@ -0,0 +1,13 @@
+CREATE SCHEMA IF NOT EXISTS togo;
+
+CREATE TABLE IF NOT EXISTS togo.tasks (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR NOT NULL,
+    created_on TIMESTAMPTZ(6) NOT NULL,
+    completed_on TIMESTAMPTZ(6) NULL,
+    due_date TIMESTAMPTZ(6) NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_tasks_name ON togo.tasks(name);
+CREATE INDEX IF NOT EXISTS ix_tasks_due_date ON togo.tasks(due_date);
@@ -0,0 +1,8 @@
+-- Fails if several tasks share a name; rename them before migrating down.
+DROP INDEX IF EXISTS togo.ix_tasks_name;
+CREATE UNIQUE INDEX ux_tasks_name ON togo.tasks(name);
+
+ALTER TABLE togo.tasks DROP CONSTRAINT tasks_pkey;
+ALTER TABLE togo.tasks RENAME COLUMN id TO task_id;
+ALTER TABLE togo.tasks ADD COLUMN id BIGSERIAL PRIMARY KEY;
+ALTER TABLE togo.tasks DROP COLUMN task_id;
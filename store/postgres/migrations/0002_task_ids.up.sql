@@ -0,0 +1,11 @@
+-- Tasks are identified by a generated UUID instead of their name. Existing
+-- rows are given a fresh ID and names are no longer required to be unique.
+ALTER TABLE togo.tasks ADD COLUMN task_id UUID NOT NULL DEFAULT gen_random_uuid();
+ALTER TABLE togo.tasks DROP CONSTRAINT tasks_pkey;
+ALTER TABLE togo.tasks DROP COLUMN id;
+ALTER TABLE togo.tasks RENAME COLUMN task_id TO id;
+ALTER TABLE togo.tasks ALTER COLUMN id DROP DEFAULT;
+ALTER TABLE togo.tasks ADD PRIMARY KEY (id);
+
+DROP INDEX IF EXISTS togo.ux_tasks_name;
+CREATE INDEX ix_tasks_name ON togo.tasks(name);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS togo.ix_tasks_priority_due_date;
+ALTER TABLE togo.tasks DROP COLUMN IF EXISTS priority;
@@ -0,0 +1,6 @@
+-- Databases created from an early schema.sql already have a priority column
+-- without a default, so make sure the default is set either way.
+ALTER TABLE togo.tasks ADD COLUMN IF NOT EXISTS priority INT NOT NULL DEFAULT 0;
+ALTER TABLE togo.tasks ALTER COLUMN priority SET DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS ix_tasks_priority_due_date ON togo.tasks(priority, due_date);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS togo.ix_tasks_open_due_date_priority;
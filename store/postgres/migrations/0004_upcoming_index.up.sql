@@ -0,0 +1,5 @@
+-- Upcoming tasks are read as a due date range over open tasks, ordered by
+-- due date and then priority.
+CREATE INDEX IF NOT EXISTS ix_tasks_open_due_date_priority
+    ON togo.tasks(due_date, priority DESC)
+    WHERE completed_on IS NULL;
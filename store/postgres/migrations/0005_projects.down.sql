@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS togo.ix_tasks_project_id;
+ALTER TABLE togo.tasks DROP COLUMN IF EXISTS project_id;
+DROP TABLE IF EXISTS togo.projects;
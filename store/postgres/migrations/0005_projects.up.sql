@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS togo.projects (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR NOT NULL,
+    created_on TIMESTAMPTZ(6) NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_projects_name ON togo.projects(name);
+
+-- removing a project keeps its tasks
+ALTER TABLE togo.tasks
+    ADD COLUMN project_id UUID NULL REFERENCES togo.projects(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS ix_tasks_project_id ON togo.tasks(project_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS togo.ix_tasks_parent_id;
+ALTER TABLE togo.tasks DROP CONSTRAINT IF EXISTS ck_tasks_parent_is_not_self;
+ALTER TABLE togo.tasks DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,7 @@
+-- Tasks can be subtasks of another task. Removing a task keeps its subtasks
+-- as top-level tasks unless the store is asked to remove them too.
+ALTER TABLE togo.tasks
+    ADD COLUMN parent_id UUID NULL REFERENCES togo.tasks(id) ON DELETE SET NULL,
+    ADD CONSTRAINT ck_tasks_parent_is_not_self CHECK (parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS ix_tasks_parent_id ON togo.tasks(parent_id);
@@ -0,0 +1 @@
+ALTER TABLE togo.tasks DROP COLUMN IF EXISTS recurrence;
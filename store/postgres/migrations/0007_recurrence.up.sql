@@ -0,0 +1,3 @@
+-- Recurring tasks keep their rule as RRULE text; completing an occurrence
+-- inserts the next one as a new row.
+ALTER TABLE togo.tasks ADD COLUMN recurrence TEXT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE togo.tasks DROP COLUMN IF EXISTS due_has_time;
+ALTER TABLE togo.tasks DROP COLUMN IF EXISTS due_zone;
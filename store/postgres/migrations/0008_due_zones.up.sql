@@ -0,0 +1,7 @@
+-- Due dates keep the IANA time zone they were set in and whether they include
+-- a time of day. Earlier due dates were all stored as midnight UTC.
+ALTER TABLE togo.tasks
+    ADD COLUMN due_zone TEXT NULL,
+    ADD COLUMN due_has_time BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE togo.tasks SET due_zone = 'UTC' WHERE due_date IS NOT NULL;
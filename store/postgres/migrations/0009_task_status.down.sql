@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS togo.ix_tasks_status;
+ALTER TABLE togo.tasks DROP COLUMN IF EXISTS transitions;
+ALTER TABLE togo.tasks DROP COLUMN IF EXISTS status;
@@ -0,0 +1,10 @@
+-- Tasks move through statuses beyond open and completed. Every change of
+-- status is kept in transitions, a JSON array ordered oldest first.
+ALTER TABLE togo.tasks
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'in_progress', 'blocked', 'waiting', 'completed', 'cancelled')),
+    ADD COLUMN transitions JSONB NOT NULL DEFAULT '[]';
+
+UPDATE togo.tasks SET status = 'completed' WHERE completed_on IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS ix_tasks_status ON togo.tasks (status);
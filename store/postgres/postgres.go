@@ -2,43 +2,150 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/peschkaj/togo"
+	"github.com/peschkaj/togo/store"
+	"strconv"
+	"strings"
 	"time"
 )
 
+var _ store.Store = (*PgStore)(nil)
+
+// Postgres SQLSTATE codes mapped onto store errors, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	dataExceptionClass  = "22"
+	notNullViolation    = "23502"
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+	checkViolation      = "23514"
+	queryCanceled       = "57014"
+)
+
 type PgStore struct {
-	pool *pgxpool.Pool
+	pool  *pgxpool.Pool
+	clock togo.Clock
 }
 
-const addOrUpdateTask = `-- name: AddOrUpdateTask 
-INSERT INTO togo.tasks (name, description, created_on, completed_on, due_date)
-VALUES ($1, $2, $3, $4, $5)
-ON CONFLICT (name) DO UPDATE
-    SET description = $2, created_on = $3, completed_on = $4, due_date = $5;
+// taskColumns lists the columns scanned by scanTask, in order.
+const taskColumns = `id, name, description, priority, project_id, parent_id, created_on as created, completed_on as completed, due_date, due_zone, due_has_time, recurrence, status, transitions`
+
+// addOrUpdateTask only writes the task when it isn't among the ancestors of its
+// new parent, so the write can't close a loop in the task hierarchy. Updates
+// keep the saved status, completion time and transitions.
+const addOrUpdateTask = `-- name: AddOrUpdateTask
+WITH RECURSIVE ancestors AS (
+    SELECT id, parent_id FROM togo.tasks WHERE id = $9::uuid
+    UNION
+    SELECT t.id, t.parent_id FROM togo.tasks t JOIN ancestors a ON t.id = a.parent_id
+)
+INSERT INTO togo.tasks (id, name, description, priority, project_id, created_on, completed_on, due_date, parent_id, recurrence, due_zone, due_has_time, status, transitions)
+SELECT $1::uuid, $2::varchar, $3::varchar, $4::int, $5::uuid, $6::timestamptz, $7::timestamptz, $8::timestamptz, $9::uuid, $10::text, $11::text, $12::boolean, $13::text, $14::jsonb
+WHERE NOT EXISTS (SELECT 1 FROM ancestors WHERE id = $1::uuid)
+ON CONFLICT (id) DO UPDATE
+    SET name = $2, description = $3, priority = $4, project_id = $5, created_on = $6, due_date = $8, parent_id = $9, recurrence = $10,
+        due_zone = $11, due_has_time = $12
+    WHERE togo.tasks.status = EXCLUDED.status;
+`
+
+const findTaskStatus = `-- name: FindTaskStatus
+SELECT status FROM togo.tasks WHERE id = $1;
+`
+
+const findTaskForUpdate = `-- name: FindTaskForUpdate
+SELECT ` + taskColumns + `
+FROM togo.tasks
+WHERE id = $1
+FOR UPDATE;
+`
+
+const transitionTask = `-- name: TransitionTask
+UPDATE togo.tasks SET status = $2, completed_on = $3, transitions = $4::jsonb WHERE id = $1;
+`
+
+const moveTask = `-- name: MoveTask
+WITH RECURSIVE ancestors AS (
+    SELECT id, parent_id FROM togo.tasks WHERE id = $2
+    UNION
+    SELECT t.id, t.parent_id FROM togo.tasks t JOIN ancestors a ON t.id = a.parent_id
+)
+UPDATE togo.tasks SET parent_id = $2
+WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM ancestors WHERE id = $1);
+`
+
+const taskExists = `-- name: TaskExists
+SELECT EXISTS (SELECT 1 FROM togo.tasks WHERE id = $1);
+`
+
+const findSubtasks = `-- name: FindSubtasks
+SELECT ` + taskColumns + `
+FROM togo.tasks
+WHERE parent_id = $1;
+`
+
+const removeTaskTree = `-- name: RemoveTaskTree
+WITH RECURSIVE tree AS (
+    SELECT id FROM togo.tasks WHERE id = $1
+    UNION
+    SELECT t.id FROM togo.tasks t JOIN tree ON t.parent_id = tree.id
+)
+DELETE FROM togo.tasks WHERE id IN (SELECT id FROM tree);
+`
+
+const removeTaskWithoutSubtasks = `-- name: RemoveTaskWithoutSubtasks
+DELETE FROM togo.tasks
+WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM togo.tasks WHERE parent_id = $1);
 `
 
 const removeTask = `-- name: RemoveTask
-DELETE FROM togo.tasks WHERE name = $1;
+DELETE FROM togo.tasks WHERE id = $1;
+`
+
+const findTask = `-- name: FindTask
+SELECT ` + taskColumns + `
+FROM togo.tasks 
+WHERE id = $1;
 `
 
-const findTaskByName = `-- name: FindTaskByName 
-SELECT name, description, created_on as created, completed_on as completed, due_date
+const findTasksByName = `-- name: FindTasksByName 
+SELECT ` + taskColumns + `
 FROM togo.tasks 
 WHERE name = $1;
 `
 
 const findTasksByDueDate = `-- name: FindTasksByDueDate
-SELECT name, description, created_on as created, completed_on as completed, due_date
+SELECT ` + taskColumns + `
+FROM togo.tasks 
+WHERE due_date >= $1 AND due_date < $2
+ORDER BY due_date;
+`
+
+const findTasksWithoutDueDate = `-- name: FindTasksWithoutDueDate
+SELECT ` + taskColumns + `
 FROM togo.tasks 
-WHERE due_date BETWEEN $1 AND $2;
+WHERE due_date IS NULL;
 `
 
+// findOverdueTasks compares due times with now ($1) and due days with the
+// start of today ($2), tasks due today aren't overdue until the day is over.
 const findOverdueTasks = `-- name: FindOverdueTasks
-SELECT name, description, created_on as created, completed_on as completed, due_date 
+SELECT ` + taskColumns + `
 FROM togo.tasks 
-WHERE due_date < CURRENT_TIMESTAMP;
+WHERE due_date < CASE WHEN due_has_time THEN $1::timestamptz ELSE $2::timestamptz END;
+`
+
+const findUpcomingTasks = `-- name: FindUpcomingTasks
+SELECT ` + taskColumns + `
+FROM togo.tasks
+WHERE due_date >= $1 AND due_date < $2 AND ($3 OR (completed_on IS NULL AND status <> 'cancelled'))
+ORDER BY due_date, priority DESC, ` + tiebreakColumns + `;
 `
 
 const countTasks = `-- name: CountTasks
@@ -46,150 +153,784 @@ SELECT COUNT(*) FROM togo.Tasks;
 `
 
 const allTasks = `-- name: AllTasks
-SELECT name, description, created_on as created, completed_on as completed, due_date 
+SELECT ` + taskColumns + `
 FROM togo.tasks 
 `
 
-func NewPgStore(connectionURI string) PgStore {
-	p, err := pgxpool.New(context.TODO(), connectionURI)
+const listTasks = `-- name: ListTasks
+SELECT ` + taskColumns + `
+FROM togo.tasks
+WHERE id > $1
+ORDER BY id
+LIMIT $2;
+`
+
+// queryTasksPrefix is completed by taskQuery with the filters and ordering of
+// a store.Query.
+const queryTasksPrefix = `-- name: QueryTasks
+SELECT ` + taskColumns + `
+FROM togo.tasks
+`
+
+const findTasksInProject = `-- name: FindTasksInProject
+SELECT ` + taskColumns + `
+FROM togo.tasks
+WHERE project_id = $1;
+`
+
+const addOrUpdateProject = `-- name: AddOrUpdateProject
+INSERT INTO togo.projects (id, name, description, created_on)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (id) DO UPDATE
+    SET name = $2, description = $3, created_on = $4;
+`
+
+const removeProject = `-- name: RemoveProject
+DELETE FROM togo.projects WHERE id = $1;
+`
+
+const findProject = `-- name: FindProject
+SELECT id, name, description, created_on as created
+FROM togo.projects
+WHERE id = $1;
+`
+
+const findProjectByName = `-- name: FindProjectByName
+SELECT id, name, description, created_on as created
+FROM togo.projects
+WHERE name = $1;
+`
+
+const allProjects = `-- name: AllProjects
+SELECT id, name, description, created_on as created
+FROM togo.projects
+ORDER BY name;
+`
+
+// config holds the settings Options apply to a new PgStore.
+type config struct {
+	pool  *pgxpool.Config
+	clock togo.Clock
+}
+
+// Option configures a PgStore and the connection pool it uses.
+type Option func(*config)
+
+// WithMaxConns limits the number of open connections in the pool.
+func WithMaxConns(n int32) Option {
+	return func(c *config) {
+		c.pool.MaxConns = n
+	}
+}
+
+// WithHealthCheckPeriod sets how often idle connections are checked.
+func WithHealthCheckPeriod(d time.Duration) Option {
+	return func(c *config) {
+		c.pool.HealthCheckPeriod = d
+	}
+}
+
+// WithStatementTimeout aborts any statement that runs longer than d.
+func WithStatementTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.pool.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(d.Milliseconds(), 10)
+	}
+}
+
+// WithApplicationName reports name as the application_name of every
+// connection, which makes the store's sessions easy to find in pg_stat_activity.
+func WithApplicationName(name string) Option {
+	return func(c *config) {
+		c.pool.ConnConfig.RuntimeParams["application_name"] = name
+	}
+}
+
+// WithClock makes the store read the current time from clock instead of the
+// system clock. The time is passed to every query that depends on it, the
+// database's own clock is never used.
+func WithClock(clock togo.Clock) Option {
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+// NewPgStore connects to the database at connectionURI and pings it before
+// returning, so a misconfigured or unreachable database is reported at
+// startup. Options override the settings in connectionURI. Call Close to shut
+// the pool down.
+func NewPgStore(ctx context.Context, connectionURI string, opts ...Option) (*PgStore, error) {
+	config, err := newConfig(connectionURI, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config.pool)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create postgres pool: %w", err)
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("cannot connect to postgres backing store: %w", err)
+	}
+
+	return &PgStore{pool: pool, clock: config.clock}, nil
+}
+
+func newConfig(connectionURI string, opts ...Option) (config, error) {
+	pool, err := pgxpool.ParseConfig(connectionURI)
 	if err != nil {
-		panic("cannot connect to postgres backing store")
+		return config{}, fmt.Errorf("invalid postgres connection URI: %w", err)
+	}
+
+	c := config{pool: pool, clock: togo.SystemClock}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c, nil
+}
+
+// Close waits for in-flight queries and closes every connection in the pool.
+func (p *PgStore) Close() {
+	p.pool.Close()
+}
+
+func (p *PgStore) AddOrUpdateTask(ctx context.Context, t togo.Task) error {
+	const op = "AddOrUpdateTask"
+	if err := store.ValidateTask(op, t); err != nil {
+		return err
 	}
 
-	return PgStore{pool: p}
+	args, err := taskArgs(t)
+	if err != nil {
+		return mapError(op, err)
+	}
+	tag, err := p.pool.Exec(ctx, addOrUpdateTask, args...)
+	if err != nil {
+		return mapError(op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return p.explainSkippedSave(ctx, op, t)
+	}
+	return nil
+}
+
+// explainSkippedSave reports why addOrUpdateTask saved nothing: either the
+// saved task has another status or t's parent would make a loop.
+func (p *PgStore) explainSkippedSave(ctx context.Context, op string, t togo.Task) error {
+	var name string
+	err := p.pool.QueryRow(ctx, findTaskStatus, t.ID).Scan(&name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return store.Invalid(op, errSubtaskLoop)
+	}
+	if err != nil {
+		return mapError(op, err)
+	}
+	saved, err := togo.ParseStatus(name)
+	if err != nil {
+		return mapError(op, err)
+	}
+	if saved != t.Status {
+		return store.StatusChanged(op, saved, t.Status)
+	}
+	return store.Invalid(op, errSubtaskLoop)
 }
 
-func (p PgStore) AddOrUpdateTask(t togo.Task) error {
-	_, err := p.pool.Exec(context.TODO(),
-		addOrUpdateTask,
+var errSubtaskLoop = errors.New("a task can't be a subtask of itself or of its own subtasks")
+
+// taskArgs returns the arguments of addOrUpdateTask for t.
+func taskArgs(t togo.Task) ([]any, error) {
+	var recurrence, dueZone *string
+	if t.Recurrence != nil {
+		rule := t.Recurrence.String()
+		recurrence = &rule
+	}
+	if zone := t.DueZone(); zone != nil {
+		name := zone.String()
+		dueZone = &name
+	}
+	transitions, err := encodeTransitions(t.Transitions)
+	if err != nil {
+		return nil, err
+	}
+	return []any{
+		t.ID,
 		t.Name,
 		t.Description,
+		t.Priority,
+		t.ProjectID,
 		t.Created,
 		t.Completed,
 		t.DueOn(),
-	)
+		t.ParentID,
+		recurrence,
+		dueZone,
+		t.HasDueTime,
+		t.Status.String(),
+		transitions,
+	}, nil
+}
+
+// transitionRow is a togo.Transition as it is kept in the transitions column.
+type transitionRow struct {
+	From string    `json:"from"`
+	To   string    `json:"to"`
+	At   time.Time `json:"at"`
+	By   string    `json:"by,omitempty"`
+}
+
+// encodeTransitions returns the JSON array kept in the transitions column.
+func encodeTransitions(transitions []togo.Transition) (string, error) {
+	rows := make([]transitionRow, 0, len(transitions))
+	for _, transition := range transitions {
+		rows = append(rows, transitionRow{From: transition.From.String(), To: transition.To.String(), At: transition.At, By: transition.By})
+	}
+	data, err := json.Marshal(rows)
+	return string(data), err
+}
+
+func decodeTransitions(data []byte) ([]togo.Transition, error) {
+	var rows []transitionRow
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	transitions := make([]togo.Transition, 0, len(rows))
+	for _, row := range rows {
+		from, err := togo.ParseStatus(row.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := togo.ParseStatus(row.To)
+		if err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, togo.Transition{From: from, To: to, At: row.At, By: row.By})
+	}
+	return transitions, nil
+}
+
+func (p *PgStore) CompleteTask(ctx context.Context, id uuid.UUID) (store.Completion, error) {
+	return p.transition(ctx, "CompleteTask", id, togo.Completed)
+}
+
+func (p *PgStore) ReopenTask(ctx context.Context, id uuid.UUID) (togo.Task, error) {
+	completion, err := p.transition(ctx, "ReopenTask", id, togo.Open)
+	return completion.Task, err
+}
+
+func (p *PgStore) TransitionTask(ctx context.Context, id uuid.UUID, to togo.Status) (store.Completion, error) {
+	return p.transition(ctx, "TransitionTask", id, to)
+}
+
+// transition locks the task's row so that completing it concurrently can't
+// create its next occurrence twice.
+func (p *PgStore) transition(ctx context.Context, op string, id uuid.UUID, to togo.Status) (store.Completion, error) {
+	if err := to.Validate(); err != nil {
+		return store.Completion{}, store.Invalid(op, err)
+	}
+
+	var completion store.Completion
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		t, err := scanTask(tx.QueryRow(ctx, findTaskForUpdate, id))
+		if err != nil {
+			return err
+		}
+		completion.Task = t
+		if t.Status == to {
+			return nil
+		}
+
+		now := p.clock.Now()
+		if err := t.TransitionBy(to, now, store.Actor(ctx)); err != nil {
+			return store.Conflict(op, err)
+		}
+		transitions, err := encodeTransitions(t.Transitions)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, transitionTask, t.ID, t.Status.String(), t.Completed, transitions); err != nil {
+			return err
+		}
+		completion.Task = t
+		if to != togo.Completed {
+			return nil
+		}
+
+		next, ok := store.NextOccurrence(t, now)
+		if !ok {
+			return nil
+		}
+		args, err := taskArgs(next)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, addOrUpdateTask, args...); err != nil {
+			return err
+		}
+		completion.Next = &next
+		return nil
+	})
 	if err != nil {
+		return store.Completion{}, mapError(op, err)
+	}
+	return completion, nil
+}
+
+func (p *PgStore) RemoveTask(ctx context.Context, id uuid.UUID) error {
+	return p.removeTask(ctx, "RemoveTask", id, store.OrphanSubtasks)
+}
+
+func (p *PgStore) RemoveTaskWith(ctx context.Context, id uuid.UUID, rule store.RemoveRule) error {
+	return p.removeTask(ctx, "RemoveTaskWith", id, rule)
+}
+
+// removeTask leaves orphaned subtasks to the parent_id foreign key's ON DELETE
+// SET NULL.
+func (p *PgStore) removeTask(ctx context.Context, op string, id uuid.UUID, rule store.RemoveRule) error {
+	if err := store.ValidateRemoveRule(op, rule); err != nil {
 		return err
 	}
-	return nil
+
+	query := removeTask
+	switch rule {
+	case store.RemoveSubtasks:
+		query = removeTaskTree
+	case store.RestrictSubtasks:
+		query = removeTaskWithoutSubtasks
+	}
+
+	tag, err := p.pool.Exec(ctx, query, id)
+	if err != nil {
+		return mapError(op, err)
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	// nothing was removed, either there's no such task or it has subtasks
+	exists, err := p.taskExists(ctx, op, id)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return store.Conflict(op, errors.New("task has subtasks"))
+	}
+	return store.NotFound(op, nil)
 }
 
-func (p PgStore) RemoveTask(taskName string) error {
-	_, err := p.pool.Exec(context.TODO(),
-		removeTask,
-		taskName)
+func (p *PgStore) Subtasks(ctx context.Context, id uuid.UUID) ([]togo.Task, error) {
+	const op = "Subtasks"
+	exists, err := p.taskExists(ctx, op, id)
 	if err != nil {
-		return errors.New("unable to remove task")
+		return nil, err
 	}
-	return nil
+	if !exists {
+		return nil, store.NotFound(op, nil)
+	}
+	return p.queryTasks(ctx, op, findSubtasks, id)
 }
 
-func (p PgStore) FindTaskByName(name string) (togo.Task, error) {
-	row := p.pool.QueryRow(context.TODO(), findTaskByName, name)
-	var i togo.Task
-	err := row.Scan(
-		&i.Name,
-		&i.Description,
-		&i.Created,
-		&i.Completed,
-		&i.DueDate,
-	)
+func (p *PgStore) MoveTask(ctx context.Context, id uuid.UUID, parent *uuid.UUID) error {
+	const op = "MoveTask"
+	tag, err := p.pool.Exec(ctx, moveTask, id, parent)
+	if err != nil {
+		return mapError(op, err)
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	exists, err := p.taskExists(ctx, op, id)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return store.Invalid(op, errSubtaskLoop)
+	}
+	return store.NotFound(op, nil)
+}
+
+func (p *PgStore) taskExists(ctx context.Context, op string, id uuid.UUID) (bool, error) {
+	var exists bool
+	if err := p.pool.QueryRow(ctx, taskExists, id).Scan(&exists); err != nil {
+		return false, mapError(op, err)
+	}
+	return exists, nil
+}
+
+func (p *PgStore) FindTask(ctx context.Context, id uuid.UUID) (togo.Task, error) {
+	row := p.pool.QueryRow(ctx, findTask, id)
+	t, err := scanTask(row)
+	if err != nil {
+		return togo.Task{}, mapError("FindTask", err)
+	}
+	return t, nil
+}
+
+func (p *PgStore) FindTasksByName(ctx context.Context, name string) ([]togo.Task, error) {
+	return p.queryTasks(ctx, "FindTasksByName", findTasksByName, name)
+}
+
+// FindByDueDate returns every task due on the same day as dueDate in loc. A
+// nil dueDate finds the tasks that have no due date at all.
+func (p *PgStore) FindByDueDate(ctx context.Context, dueDate *time.Time, loc *time.Location) ([]togo.Task, error) {
+	if dueDate == nil {
+		return p.queryTasks(ctx, "FindByDueDate", findTasksWithoutDueDate)
+	}
+
+	start, end := store.DaySpan(*dueDate, *dueDate, loc)
+	return p.queryTasks(ctx, "FindByDueDate", findTasksByDueDate, start, end)
+}
+
+func (p *PgStore) FindDueBetween(ctx context.Context, start, end time.Time, loc *time.Location) ([]togo.Task, error) {
+	spanStart, spanEnd := store.DaySpan(start, end, loc)
+	return p.queryTasks(ctx, "FindDueBetween", findTasksByDueDate, spanStart, spanEnd)
+}
+
+func (p *PgStore) OverdueTasks(ctx context.Context, loc *time.Location) ([]togo.Task, error) {
+	now := p.clock.Now()
+	return p.queryTasks(ctx, "OverdueTasks", findOverdueTasks, now, togo.TodayAt(now, loc))
+}
+
+func (p *PgStore) Upcoming(ctx context.Context, window store.UpcomingWindow) ([]togo.Task, error) {
+	start, end := window.Span(p.clock.Now())
+	return p.queryTasks(ctx, "Upcoming", findUpcomingTasks, start, end, window.IncludeCompleted)
+}
 
-	return i, err
+func (p *PgStore) Count(ctx context.Context) (int, error) {
+	var count int
+	if err := p.pool.QueryRow(ctx, countTasks).Scan(&count); err != nil {
+		return 0, mapError("Count", err)
+	}
+	return count, nil
 }
 
-func (p PgStore) FindTasksByDueDate(d time.Time) ([]togo.Task, error) {
-	start := timeToDate(d)
-	end := timeToDate(d).Add(24 * time.Hour)
+func (p *PgStore) All(ctx context.Context) ([]togo.Task, error) {
+	return p.queryTasks(ctx, "All", allTasks)
+}
 
-	rows, err := p.pool.Query(context.TODO(), findTasksByDueDate, start, end)
+// ListTasks uses keyset pagination on the primary key. One task more than the
+// page holds is fetched to learn whether another page follows.
+func (p *PgStore) ListTasks(ctx context.Context, page store.Page) (store.TaskPage, error) {
+	after, err := store.DecodeCursor("ListTasks", page.Cursor)
 	if err != nil {
+		return store.TaskPage{}, err
+	}
+	size := page.Size()
+
+	tasks, err := p.queryTasks(ctx, "ListTasks", listTasks, after, size+1)
+	if err != nil {
+		return store.TaskPage{}, err
+	}
+
+	result := store.TaskPage{Tasks: tasks}
+	if len(tasks) > size {
+		result.Tasks = tasks[:size]
+		result.Next = store.EncodeCursor(tasks[size-1].ID)
+	}
+	return result, nil
+}
+
+func (p *PgStore) QueryTasks(ctx context.Context, q store.Query) ([]togo.Task, error) {
+	if err := store.ValidateQuery("QueryTasks", q); err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-	tasks := []togo.Task{}
-	for rows.Next() {
-		var t togo.Task
-		if err := rows.Scan(
-			&t.Name,
-			&t.Description,
-			&t.Created,
-			&t.Completed,
-			&t.DueDate,
-		); err != nil {
-			return nil, err
+
+	query, args := taskQuery(q)
+	return p.queryTasks(ctx, "QueryTasks", query, args...)
+}
+
+// sortColumns maps sort fields onto the columns they order by. Names are
+// compared byte by byte, like togo.ByTitle does, rather than by the database's
+// collation.
+var sortColumns = map[store.SortField]string{
+	store.SortByTitle:    `name COLLATE "C"`,
+	store.SortByDueDate:  "due_date",
+	store.SortByPriority: "priority",
+	store.SortByCreated:  "created_on",
+}
+
+// tiebreakColumns order rows left tied by the sort keys the same way as the
+// tiebreakers of togo.Tasks.SortBy.
+const tiebreakColumns = `name COLLATE "C", created_on, id`
+
+// orderBy maps sort keys onto an ORDER BY clause matching the order of
+// togo.Tasks.SortBy with the keys' comparators. Without any keys rows are
+// ordered by ID.
+func orderBy(keys ...store.SortKey) string {
+	if len(keys) == 0 {
+		return "ORDER BY id"
+	}
+
+	order := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		direction := " ASC"
+		if key.Descending {
+			direction = " DESC"
 		}
-		tasks = append(tasks, t)
+		// undated tasks are last either way, like togo.ByDueDateDescending
+		order = append(order, sortColumns[key.Field]+direction+" NULLS LAST")
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+	order = append(order, tiebreakColumns)
+	return "ORDER BY " + strings.Join(order, ", ")
+}
+
+// likeEscaper escapes the LIKE wildcards in text matched by a query.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// taskQuery translates q into a SELECT over complete task rows and its
+// arguments. Every value is passed as an argument, only fixed SQL fragments are
+// added to the statement.
+func taskQuery(q store.Query) (string, []any) {
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
 	}
-	return tasks, nil
+
+	if q.Completed != nil {
+		if *q.Completed {
+			where = append(where, "status IN ('completed', 'cancelled')")
+		} else {
+			where = append(where, "status NOT IN ('completed', 'cancelled')")
+		}
+	}
+	if len(q.Statuses) > 0 {
+		statuses := make([]string, 0, len(q.Statuses))
+		for _, status := range q.Statuses {
+			statuses = append(statuses, status.String())
+		}
+		where = append(where, "status = ANY("+arg(statuses)+"::text[])")
+	}
+	if q.MinPriority != nil {
+		where = append(where, "priority >= "+arg(*q.MinPriority))
+	}
+	if q.MaxPriority != nil {
+		where = append(where, "priority <= "+arg(*q.MaxPriority))
+	}
+	start, end := q.DueSpan()
+	if start != nil {
+		where = append(where, "due_date >= "+arg(*start))
+	}
+	if end != nil {
+		where = append(where, "due_date < "+arg(*end))
+	}
+	if q.CreatedFrom != nil {
+		where = append(where, "created_on >= "+arg(*q.CreatedFrom))
+	}
+	if q.CreatedTo != nil {
+		where = append(where, "created_on < "+arg(*q.CreatedTo))
+	}
+	if q.ProjectID != nil {
+		where = append(where, "project_id = "+arg(*q.ProjectID))
+	}
+	if q.Text != "" {
+		pattern := arg("%" + likeEscaper.Replace(q.Text) + "%")
+		where = append(where, "(name ILIKE "+pattern+" OR description ILIKE "+pattern+")")
+	}
+
+	var query strings.Builder
+	query.WriteString(queryTasksPrefix)
+	if len(where) > 0 {
+		query.WriteString("WHERE " + strings.Join(where, " AND ") + "\n")
+	}
+
+	query.WriteString(orderBy(q.Sort...) + ";\n")
+
+	return query.String(), args
+}
+
+func (p *PgStore) AddOrUpdateProject(ctx context.Context, project togo.Project) error {
+	if err := store.ValidateProject("AddOrUpdateProject", project); err != nil {
+		return err
+	}
+
+	_, err := p.pool.Exec(ctx,
+		addOrUpdateProject,
+		project.ID,
+		project.Name,
+		project.Description,
+		project.Created,
+	)
+	if err != nil {
+		return mapError("AddOrUpdateProject", err)
+	}
+	return nil
 }
 
-func (p PgStore) FindOverdueTasks() ([]togo.Task, error) {
-	rows, err := p.pool.Query(context.TODO(), findOverdueTasks)
+// RemoveProject deletes the project, its tasks are kept by the foreign key's
+// ON DELETE SET NULL.
+func (p *PgStore) RemoveProject(ctx context.Context, id uuid.UUID) error {
+	tag, err := p.pool.Exec(ctx, removeProject, id)
 	if err != nil {
-		return nil, err
+		return mapError("RemoveProject", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return store.NotFound("RemoveProject", nil)
 	}
+	return nil
+}
 
+func (p *PgStore) FindProject(ctx context.Context, id uuid.UUID) (togo.Project, error) {
+	project, err := scanProject(p.pool.QueryRow(ctx, findProject, id))
+	if err != nil {
+		return togo.Project{}, mapError("FindProject", err)
+	}
+	return project, nil
+}
+
+func (p *PgStore) FindProjectByName(ctx context.Context, name string) (togo.Project, error) {
+	project, err := scanProject(p.pool.QueryRow(ctx, findProjectByName, name))
+	if err != nil {
+		return togo.Project{}, mapError("FindProjectByName", err)
+	}
+	return project, nil
+}
+
+// AllProjects returns every project ordered by name.
+func (p *PgStore) AllProjects(ctx context.Context) ([]togo.Project, error) {
+	const op = "AllProjects"
+	rows, err := p.pool.Query(ctx, allProjects)
+	if err != nil {
+		return nil, mapError(op, err)
+	}
 	defer rows.Close()
-	tasks := []togo.Task{}
+
+	projects := []togo.Project{}
 	for rows.Next() {
-		var t togo.Task
-		if err := rows.Scan(
-			&t.Name,
-			&t.Description,
-			&t.Created,
-			&t.Completed,
-			&t.DueDate,
-		); err != nil {
-			return nil, err
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, mapError(op, err)
 		}
-		tasks = append(tasks, t)
+		projects = append(projects, project)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, mapError(op, err)
 	}
-	return tasks, nil
+	return projects, nil
 }
 
-func (p PgStore) Count() (int, error) {
-	row := p.pool.QueryRow(context.TODO(), countTasks)
-	var count int
-	err := row.Scan(&count)
-
-	return count, err
+func (p *PgStore) TasksInProject(ctx context.Context, id uuid.UUID) ([]togo.Task, error) {
+	// distinguish an unknown project from one without any tasks
+	if _, err := scanProject(p.pool.QueryRow(ctx, findProject, id)); err != nil {
+		return nil, mapError("TasksInProject", err)
+	}
+	return p.queryTasks(ctx, "TasksInProject", findTasksInProject, id)
 }
 
-func (p PgStore) All() ([]togo.Task, error) {
-	rows, err := p.pool.Query(context.TODO(), allTasks)
+// queryTasks runs a query selecting complete task rows and collects the
+// results. Errors are reported as failures of op.
+func (p *PgStore) queryTasks(ctx context.Context, op, query string, args ...any) ([]togo.Task, error) {
+	rows, err := p.pool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, mapError(op, err)
 	}
-
 	defer rows.Close()
+
 	tasks := []togo.Task{}
 	for rows.Next() {
-		var t togo.Task
-		if err := rows.Scan(
-			&t.Name,
-			&t.Description,
-			&t.Created,
-			&t.Completed,
-			&t.DueDate,
-		); err != nil {
-			return nil, err
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, mapError(op, err)
 		}
 		tasks = append(tasks, t)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, mapError(op, err)
 	}
 	return tasks, nil
 }
 
-func timeToDate(t time.Time) time.Time {
-	yyyy, mm, dd := t.Date()
-	return time.Date(yyyy, mm, dd, 0, 0, 0, 0, t.Location())
+func scanTask(row pgx.Row) (togo.Task, error) {
+	var t togo.Task
+	var dueZone, recurrence *string
+	var status string
+	var transitions []byte
+	err := row.Scan(
+		&t.ID,
+		&t.Name,
+		&t.Description,
+		&t.Priority,
+		&t.ProjectID,
+		&t.ParentID,
+		&t.Created,
+		&t.Completed,
+		&t.DueDate,
+		&dueZone,
+		&t.HasDueTime,
+		&recurrence,
+		&status,
+		&transitions,
+	)
+	if err != nil {
+		return t, err
+	}
+
+	if t.DueDate != nil && dueZone != nil {
+		loc, err := togo.LoadZone(*dueZone)
+		if err != nil {
+			return togo.Task{}, fmt.Errorf("task %s: %w", t.ID, err)
+		}
+		due := t.DueDate.In(loc)
+		t.DueDate = &due
+	}
+	if recurrence != nil {
+		r, err := togo.ParseRecurrence(*recurrence)
+		if err != nil {
+			return togo.Task{}, fmt.Errorf("task %s: %w", t.ID, err)
+		}
+		t.Recurrence = &r
+	}
+	if t.Status, err = togo.ParseStatus(status); err != nil {
+		return togo.Task{}, fmt.Errorf("task %s: %w", t.ID, err)
+	}
+	if t.Transitions, err = decodeTransitions(transitions); err != nil {
+		return togo.Task{}, fmt.Errorf("task %s: transitions: %w", t.ID, err)
+	}
+	return t, nil
+}
+
+func scanProject(row pgx.Row) (togo.Project, error) {
+	var p togo.Project
+	err := row.Scan(
+		&p.ID,
+		&p.Name,
+		&p.Description,
+		&p.Created,
+	)
+	return p, err
+}
+
+// mapError translates pgx and Postgres errors into the store error taxonomy,
+// keeping the original error as the cause. Errors that callers can't act on,
+// including context cancellation, are returned unchanged.
+func mapError(op string, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return store.NotFound(op, err)
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch {
+	case pgErr.Code == uniqueViolation:
+		return store.Conflict(op, err)
+	case pgErr.Code == notNullViolation,
+		pgErr.Code == foreignKeyViolation,
+		pgErr.Code == checkViolation,
+		strings.HasPrefix(pgErr.Code, dataExceptionClass):
+		return store.Invalid(op, err)
+	case pgErr.Code == queryCanceled:
+		return store.Timeout(op, err)
+	}
+	return err
 }
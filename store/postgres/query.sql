@@ -1,29 +1,89 @@
 -- create schema
 CREATE SCHEMA togo;
 
+CREATE TABLE IF NOT EXISTS togo.projects (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR NOT NULL,
+    created_on TIMESTAMPTZ(6) NOT NULL
+);
+
 CREATE TABLE IF NOT EXISTS togo.tasks (
-    id BIGSERIAL PRIMARY KEY ,
+    id UUID PRIMARY KEY,
     name VARCHAR(100) NOT NULL,
     description VARCHAR NOT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    project_id UUID NULL REFERENCES togo.projects(id) ON DELETE SET NULL,
+    parent_id UUID NULL REFERENCES togo.tasks(id) ON DELETE SET NULL CHECK (parent_id <> id),
     created_on TIMESTAMPTZ(6) NOT NULL,
     completed_on TIMESTAMPTZ(6) NULL,
-    due_date TIMESTAMPTZ(6) NULL
+    due_date TIMESTAMPTZ(6) NULL,
+    recurrence TEXT NULL,
+    due_zone TEXT NULL,
+    due_has_time BOOLEAN NOT NULL DEFAULT false,
+    status TEXT NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'in_progress', 'blocked', 'waiting', 'completed', 'cancelled')),
+    transitions JSONB NOT NULL DEFAULT '[]'
 );
 
--- name: AddOrUpdateTask :exec
-INSERT INTO togo.tasks (name, description, created_on, completed_on, due_date)
-VALUES ($1, $2, $3, $4, $5)
-ON CONFLICT (name) DO UPDATE
-    SET description = $2, created_on = $3, completed_on = $4, due_date = $5;
+-- name: AddOrUpdateTask :execrows
+WITH RECURSIVE ancestors AS (
+    SELECT id, parent_id FROM togo.tasks WHERE id = $9::uuid
+    UNION
+    SELECT t.id, t.parent_id FROM togo.tasks t JOIN ancestors a ON t.id = a.parent_id
+)
+INSERT INTO togo.tasks (id, name, description, priority, project_id, created_on, completed_on, due_date, parent_id, recurrence, due_zone, due_has_time, status, transitions)
+SELECT $1::uuid, $2::varchar, $3::varchar, $4::int, $5::uuid, $6::timestamptz, $7::timestamptz, $8::timestamptz, $9::uuid, $10::text, $11::text, $12::boolean, $13::text, $14::jsonb
+WHERE NOT EXISTS (SELECT 1 FROM ancestors WHERE id = $1::uuid)
+ON CONFLICT (id) DO UPDATE
+    SET name = $2, description = $3, priority = $4, project_id = $5, created_on = $6, due_date = $8, parent_id = $9, recurrence = $10,
+        due_zone = $11, due_has_time = $12
+    WHERE togo.tasks.status = EXCLUDED.status;
+
+-- name: FindTaskStatus :one
+SELECT status FROM togo.tasks WHERE id = $1;
+
+-- name: FindTaskForUpdate :one
+SELECT * FROM togo.tasks WHERE id = $1 FOR UPDATE;
+
+-- name: TransitionTask :exec
+UPDATE togo.tasks SET status = $2, completed_on = $3, transitions = $4::jsonb WHERE id = $1;
+
+-- name: MoveTask :execrows
+WITH RECURSIVE ancestors AS (
+    SELECT id, parent_id FROM togo.tasks WHERE id = $2
+    UNION
+    SELECT t.id, t.parent_id FROM togo.tasks t JOIN ancestors a ON t.id = a.parent_id
+)
+UPDATE togo.tasks SET parent_id = $2
+WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM ancestors WHERE id = $1);
+
+-- name: TaskExists :one
+SELECT EXISTS (SELECT 1 FROM togo.tasks WHERE id = $1);
+
+-- name: FindSubtasks :many
+SELECT * FROM togo.tasks WHERE parent_id = $1;
+
+-- name: FindTask :one
+SELECT * FROM togo.tasks WHERE id = $1;
 
--- name: FindByName :one
+-- name: FindByName :many
 SELECT * FROM togo.tasks WHERE name = $1;
 
 -- name: FindByDueDate :many
-SELECT * FROM togo.tasks WHERE due_date = $1;
+SELECT * FROM togo.tasks WHERE due_date >= $1 AND due_date < $2 ORDER BY due_date;
+
+-- name: FindWithoutDueDate :many
+SELECT * FROM togo.tasks WHERE due_date IS NULL;
 
 -- name: FindOverdueTasks :many
-SELECT * FROM togo.tasks WHERE due_date < $1;
+SELECT * FROM togo.tasks
+WHERE due_date < CASE WHEN due_has_time THEN $1::timestamptz ELSE $2::timestamptz END;
+
+-- name: FindUpcomingTasks :many
+SELECT * FROM togo.tasks
+WHERE due_date >= $1 AND due_date < $2 AND ($3 OR (completed_on IS NULL AND status <> 'cancelled'))
+ORDER BY due_date, priority DESC, name COLLATE "C", created_on, id;
 
 -- name: CountTasks :one
 SELECT COUNT(*) FROM togo.tasks;
@@ -31,5 +91,44 @@ SELECT COUNT(*) FROM togo.tasks;
 -- name: AllTasks :many
 SELECT * FROM togo.tasks;
 
+-- name: ListTasks :many
+SELECT * FROM togo.tasks
+WHERE id > $1
+ORDER BY id
+LIMIT $2;
+
 -- name: RemoveTask :exec
-DELETE FROM togo.tasks WHERE name = $1;
+DELETE FROM togo.tasks WHERE id = $1;
+
+-- name: RemoveTaskTree :execrows
+WITH RECURSIVE tree AS (
+    SELECT id FROM togo.tasks WHERE id = $1
+    UNION
+    SELECT t.id FROM togo.tasks t JOIN tree ON t.parent_id = tree.id
+)
+DELETE FROM togo.tasks WHERE id IN (SELECT id FROM tree);
+
+-- name: RemoveTaskWithoutSubtasks :execrows
+DELETE FROM togo.tasks
+WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM togo.tasks WHERE parent_id = $1);
+
+-- name: FindTasksInProject :many
+SELECT * FROM togo.tasks WHERE project_id = $1;
+
+-- name: AddOrUpdateProject :exec
+INSERT INTO togo.projects (id, name, description, created_on)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (id) DO UPDATE
+    SET name = $2, description = $3, created_on = $4;
+
+-- name: RemoveProject :exec
+DELETE FROM togo.projects WHERE id = $1;
+
+-- name: FindProject :one
+SELECT * FROM togo.projects WHERE id = $1;
+
+-- name: FindProjectByName :one
+SELECT * FROM togo.projects WHERE name = $1;
+
+-- name: AllProjects :many
+SELECT * FROM togo.projects ORDER BY name;
@@ -0,0 +1,299 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/peschkaj/togo"
+	"strings"
+	"time"
+)
+
+// Query selects and orders the tasks returned by Store.QueryTasks. The zero
+// Query matches every task. Each filter that is set narrows the result further,
+// so a task has to match all of them. Queries are built by chaining:
+//
+//	q := store.Query{}.
+//		WhereCompleted(false).
+//		WherePriority(togo.Medium, togo.High).
+//		OrderBy(store.Desc(store.SortByPriority), store.Asc(store.SortByDueDate))
+type Query struct {
+	// Completed matches finished tasks, completed or cancelled, when true and
+	// unfinished ones when false.
+	Completed *bool
+	// Statuses matches tasks in any of the statuses, every task when empty.
+	Statuses []togo.Status
+	// MinPriority and MaxPriority bound the priority, inclusive.
+	MinPriority *togo.Priority
+	MaxPriority *togo.Priority
+	// DueFrom and DueTo bound the due date by calendar day, inclusive, with
+	// the days in Location. Tasks without a due date never match a due date
+	// bound.
+	DueFrom *time.Time
+	DueTo   *time.Time
+	// Location is the time zone of the DueFrom and DueTo days, UTC when nil.
+	Location *time.Location
+	// CreatedFrom and CreatedTo bound the creation time, from inclusive and
+	// to exclusive.
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	ProjectID   *uuid.UUID
+	// Text matches tasks whose name or description contains it, ignoring case.
+	Text string
+	// Sort orders the results by each key in turn. Tasks that are still tied
+	// are ordered by name, then creation time, then ID, the same way as
+	// togo.Tasks.SortBy. Tasks are ordered by ID when Sort is empty.
+	Sort []SortKey
+}
+
+// WhereCompleted matches finished tasks, see togo.Status.IsFinished, when
+// completed is true and unfinished ones otherwise.
+func (q Query) WhereCompleted(completed bool) Query {
+	q.Completed = &completed
+	return q
+}
+
+// WhereStatus matches tasks in any of the given statuses.
+func (q Query) WhereStatus(statuses ...togo.Status) Query {
+	q.Statuses = append([]togo.Status(nil), statuses...)
+	return q
+}
+
+// WherePriority matches tasks with a priority from min through max.
+func (q Query) WherePriority(min, max togo.Priority) Query {
+	q.MinPriority, q.MaxPriority = &min, &max
+	return q
+}
+
+// WhereDue matches tasks due on any day from from through to.
+func (q Query) WhereDue(from, to time.Time) Query {
+	q.DueFrom, q.DueTo = &from, &to
+	return q
+}
+
+// InLocation takes the days of the due date bounds to be days in loc.
+func (q Query) InLocation(loc *time.Location) Query {
+	q.Location = loc
+	return q
+}
+
+// WhereCreated matches tasks created at or after from and before to.
+func (q Query) WhereCreated(from, to time.Time) Query {
+	q.CreatedFrom, q.CreatedTo = &from, &to
+	return q
+}
+
+// WhereProject matches the tasks in the project with the given ID.
+func (q Query) WhereProject(id uuid.UUID) Query {
+	q.ProjectID = &id
+	return q
+}
+
+// WhereText matches tasks whose name or description contains text, ignoring
+// case.
+func (q Query) WhereText(text string) Query {
+	q.Text = text
+	return q
+}
+
+// OrderBy adds keys to the end of the sort order.
+func (q Query) OrderBy(keys ...SortKey) Query {
+	q.Sort = append(append([]SortKey(nil), q.Sort...), keys...)
+	return q
+}
+
+// SortField is a task attribute results can be ordered by.
+type SortField int
+
+const (
+	SortByTitle SortField = iota + 1
+	SortByDueDate
+	SortByPriority
+	SortByCreated
+)
+
+var sortFieldNames = map[SortField]string{
+	SortByTitle:    "title",
+	SortByDueDate:  "dueDate",
+	SortByPriority: "priority",
+	SortByCreated:  "created",
+}
+
+func (f SortField) String() string {
+	if name, ok := sortFieldNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("SortField(%d)", int(f))
+}
+
+// SortKey orders results by Field, ascending unless Descending is set. Tasks
+// without a due date sort after every dated task in either direction.
+type SortKey struct {
+	Field      SortField
+	Descending bool
+}
+
+// Comparator returns the comparator that orders tasks by k.
+func (k SortKey) Comparator() togo.Comparator {
+	var c togo.Comparator
+	switch k.Field {
+	case SortByTitle:
+		c = togo.ByTitle
+	case SortByDueDate:
+		if k.Descending {
+			return togo.ByDueDateDescending
+		}
+		return togo.ByDueDate
+	case SortByPriority:
+		c = togo.ByPriority
+	case SortByCreated:
+		c = togo.ByCreated
+	default:
+		return func(a, b togo.Task) int { return 0 }
+	}
+
+	if k.Descending {
+		return togo.Reverse(c)
+	}
+	return c
+}
+
+// Asc orders results by f, smallest first.
+func Asc(f SortField) SortKey {
+	return SortKey{Field: f}
+}
+
+// Desc orders results by f, largest first.
+func Desc(f SortField) SortKey {
+	return SortKey{Field: f, Descending: true}
+}
+
+// String returns the name of the field, prefixed with "-" when descending.
+func (k SortKey) String() string {
+	if k.Descending {
+		return "-" + k.Field.String()
+	}
+	return k.Field.String()
+}
+
+// ParseSortKey reads a sort key in the form returned by SortKey.String.
+func ParseSortKey(op, s string) (SortKey, error) {
+	key := SortKey{}
+	name := s
+	if strings.HasPrefix(name, "-") {
+		key.Descending = true
+		name = name[1:]
+	}
+	for field, fieldName := range sortFieldNames {
+		if fieldName == name {
+			key.Field = field
+			return key, nil
+		}
+	}
+	return SortKey{}, Invalid(op, fmt.Errorf("cannot sort by %q", s))
+}
+
+// ValidateQuery reports unknown statuses, priorities outside of the known
+// levels and unknown sort fields. Empty ranges are not an error, they match
+// nothing.
+func ValidateQuery(op string, q Query) error {
+	for _, status := range q.Statuses {
+		if err := status.Validate(); err != nil {
+			return Invalid(op, err)
+		}
+	}
+	for _, p := range []*togo.Priority{q.MinPriority, q.MaxPriority} {
+		if p != nil && (*p < togo.None || *p > togo.High) {
+			return Invalid(op, fmt.Errorf("unknown priority %d", *p))
+		}
+	}
+	for _, key := range q.Sort {
+		if _, ok := sortFieldNames[key.Field]; !ok {
+			return Invalid(op, errors.New("unknown sort field"))
+		}
+	}
+	return nil
+}
+
+// Matches reports whether t passes every filter in q.
+func (q Query) Matches(t togo.Task) bool {
+	if q.Completed != nil && *q.Completed != t.Status.IsFinished() {
+		return false
+	}
+	if len(q.Statuses) > 0 && !hasStatus(q.Statuses, t.Status) {
+		return false
+	}
+	if q.MinPriority != nil && t.Priority < *q.MinPriority {
+		return false
+	}
+	if q.MaxPriority != nil && t.Priority > *q.MaxPriority {
+		return false
+	}
+	if q.DueFrom != nil || q.DueTo != nil {
+		if t.DueDate == nil {
+			return false
+		}
+		start, end := q.DueSpan()
+		if start != nil && t.DueDate.Before(*start) {
+			return false
+		}
+		if end != nil && !t.DueDate.Before(*end) {
+			return false
+		}
+	}
+	if q.CreatedFrom != nil && t.Created.Before(*q.CreatedFrom) {
+		return false
+	}
+	if q.CreatedTo != nil && !t.Created.Before(*q.CreatedTo) {
+		return false
+	}
+	if q.ProjectID != nil && (t.ProjectID == nil || *t.ProjectID != *q.ProjectID) {
+		return false
+	}
+	if q.Text != "" {
+		text := strings.ToLower(q.Text)
+		if !strings.Contains(strings.ToLower(t.Name), text) && !strings.Contains(strings.ToLower(t.Description), text) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasStatus(statuses []togo.Status, status togo.Status) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// SortTasks puts tasks in the order selected by q.Sort. Tasks are ordered by
+// ID when q has no sort keys.
+func (q Query) SortTasks(tasks []togo.Task) {
+	if len(q.Sort) == 0 {
+		togo.Tasks(tasks).SortBy(togo.ByID)
+		return
+	}
+
+	order := make([]togo.Comparator, 0, len(q.Sort))
+	for _, key := range q.Sort {
+		order = append(order, key.Comparator())
+	}
+	togo.Tasks(tasks).SortBy(order...)
+}
+
+// DueSpan returns the moments bounding the due dates matched by q: the start
+// of the DueFrom day, inclusive, and the end of the DueTo day, exclusive. A
+// bound that isn't set is nil.
+func (q Query) DueSpan() (start, end *time.Time) {
+	if q.DueFrom != nil {
+		from := togo.StartOfDay(*q.DueFrom, q.Location)
+		start = &from
+	}
+	if q.DueTo != nil {
+		to := togo.StartOfDay(*q.DueTo, q.Location).AddDate(0, 0, 1)
+		end = &to
+	}
+	return start, end
+}
@@ -0,0 +1,42 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSortKeyRoundTrip(t *testing.T) {
+	for _, key := range []SortKey{Asc(SortByTitle), Desc(SortByDueDate), Desc(SortByPriority), Asc(SortByCreated)} {
+		parsed, err := ParseSortKey("QueryTasks", key.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != key {
+			t.Errorf("expected %v found %v", key, parsed)
+		}
+	}
+}
+
+func TestUnknownSortKeysAreInvalid(t *testing.T) {
+	for _, s := range []string{"", "-", "name", "--title", "Title"} {
+		if _, err := ParseSortKey("QueryTasks", s); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%q: expected %v found %v", s, ErrInvalid, err)
+		}
+	}
+}
+
+func TestOrderByDoesNotShareKeys(t *testing.T) {
+	base := Query{}.OrderBy(Asc(SortByTitle))
+	byDate := base.OrderBy(Asc(SortByDueDate))
+	byPriority := base.OrderBy(Desc(SortByPriority))
+
+	if len(base.Sort) != 1 {
+		t.Errorf("expected the base query to keep %d key found %v", 1, base.Sort)
+	}
+	if byDate.Sort[1] != Asc(SortByDueDate) {
+		t.Errorf("expected %v found %v", Asc(SortByDueDate), byDate.Sort[1])
+	}
+	if byPriority.Sort[1] != Desc(SortByPriority) {
+		t.Errorf("expected %v found %v", Desc(SortByPriority), byPriority.Sort[1])
+	}
+}
@@ -1,16 +1,200 @@
 package store
 
 import (
+	"context"
+	"github.com/google/uuid"
 	"github.com/peschkaj/togo"
 	"time"
 )
 
+// Store persists tasks and the projects they belong to. Tasks are identified
+// by their ID; names are a secondary, non-unique attribute. Project names are
+// unique, saving a project under another project's name is a conflict.
+//
+// A task can only be added to a project that has already been saved. Removing
+// a project keeps its tasks but removes them from the project.
+//
+// Tasks form hierarchies through their ParentID. A subtask's parent must have
+// been saved already and a task can't become a subtask of itself or of any of
+// its own subtasks. RemoveTask keeps a task's subtasks as top-level tasks,
+// RemoveTaskWith lets callers choose another RemoveRule.
+//
+// Due dates are moments in the task's own time zone. Lookups by day take the
+// caller's location, UTC when nil: a task is due on a day when its due date
+// falls between the midnights that start and end that day in the location.
+//
+// Completing a recurring task with CompleteTask or TransitionTask saves its
+// next occurrence as a new task in the same operation, the first time it is
+// completed; completing it again after reopening it doesn't repeat the series
+// twice. Every change of status records the actor set on the context with
+// WithActor. AddOrUpdateTask can't change the status of a saved task and
+// returns ErrConflict instead; it keeps the saved completion time and
+// transitions, and checks that those of a new task agree with its status, see
+// togo.Task.ValidateStatus.
+//
+// OverdueTasks, Upcoming, CompleteTask, TransitionTask and ReopenTask read the
+// current time from the store's togo.Clock, the system clock unless the backend
+// was given another.
+//
+// Every method takes a context so callers can cancel long-running operations
+// or bound them with a deadline; implementations return the context's error
+// once it is done.
 type Store interface {
-	AddOrUpdateTask(togo.Task) error
-	RemoveTask(togo.Task) error
-	FindTaskByName(string) (togo.Task, error)
-	FindByDueDate(*time.Time) ([]togo.Task, error)
-	OverdueTasks() ([]togo.Task, error)
-	Count() (int, error)
-	All() ([]togo.Task, error)
+	AddOrUpdateTask(context.Context, togo.Task) error
+	RemoveTask(context.Context, uuid.UUID) error
+	FindTask(context.Context, uuid.UUID) (togo.Task, error)
+	FindTasksByName(context.Context, string) ([]togo.Task, error)
+	// FindByDueDate returns the tasks due on the day of dueDate in loc, or
+	// the tasks without a due date when dueDate is nil.
+	FindByDueDate(ctx context.Context, dueDate *time.Time, loc *time.Location) ([]togo.Task, error)
+	// FindDueBetween returns the tasks due on any day from start through end,
+	// inclusive, in loc, ordered by due date.
+	FindDueBetween(ctx context.Context, start, end time.Time, loc *time.Location) ([]togo.Task, error)
+	// OverdueTasks returns the tasks that are overdue in loc, see
+	// togo.Task.Overdue.
+	OverdueTasks(ctx context.Context, loc *time.Location) ([]togo.Task, error)
+	Upcoming(context.Context, UpcomingWindow) ([]togo.Task, error)
+	Count(context.Context) (int, error)
+	All(context.Context) ([]togo.Task, error)
+	// ListTasks returns one page of every task, ordered by ID.
+	ListTasks(context.Context, Page) (TaskPage, error)
+	// QueryTasks returns the tasks matching every filter in the query, in the
+	// query's sort order.
+	QueryTasks(context.Context, Query) ([]togo.Task, error)
+	// Subtasks returns the direct subtasks of the task with the given ID.
+	Subtasks(context.Context, uuid.UUID) ([]togo.Task, error)
+	// MoveTask makes a task a subtask of parent, or a top-level task when
+	// parent is nil.
+	MoveTask(ctx context.Context, id uuid.UUID, parent *uuid.UUID) error
+	RemoveTaskWith(context.Context, uuid.UUID, RemoveRule) error
+	// CompleteTask marks a task completed and, when it recurs, saves its next
+	// occurrence. Completing a completed task changes nothing. It is the same
+	// as TransitionTask to togo.Completed.
+	CompleteTask(context.Context, uuid.UUID) (Completion, error)
+	// TransitionTask moves a task to another status and records the
+	// transition, see togo.Task.TransitionAt. Transitions the task's status
+	// doesn't allow are a conflict; moving a task to the status it already has
+	// changes nothing.
+	TransitionTask(ctx context.Context, id uuid.UUID, to togo.Status) (Completion, error)
+	// ReopenTask moves a task back to togo.Open, see togo.Task.Reopen.
+	// Reopening an open task changes nothing.
+	ReopenTask(context.Context, uuid.UUID) (togo.Task, error)
+
+	AddOrUpdateProject(context.Context, togo.Project) error
+	RemoveProject(context.Context, uuid.UUID) error
+	FindProject(context.Context, uuid.UUID) (togo.Project, error)
+	FindProjectByName(context.Context, string) (togo.Project, error)
+	AllProjects(context.Context) ([]togo.Project, error)
+	TasksInProject(context.Context, uuid.UUID) ([]togo.Task, error)
+}
+
+// UpcomingWindow selects the tasks returned by Store.Upcoming: those due today
+// or within the following Days days, with the days in Location, UTC when nil.
+// Finished tasks, completed or cancelled, are left out unless IncludeCompleted
+// is set. Results are ordered by due date, then by priority with the most
+// important first, then by name.
+type UpcomingWindow struct {
+	Days             int
+	IncludeCompleted bool
+	Location         *time.Location
+}
+
+// Span returns the moments bounding the window when the time is now, from the
+// start of today inclusive to the end of its last day exclusive.
+func (w UpcomingWindow) Span(now time.Time) (start, end time.Time) {
+	today := togo.TodayAt(now, w.Location)
+	return DaySpan(today, today.AddDate(0, 0, w.Days), w.Location)
+}
+
+// DaySpan returns the moments bounding the days from first through last in
+// loc, UTC when nil: the midnight starting first, inclusive, and the midnight
+// ending last, exclusive. The days are read in the locations of first and
+// last, see togo.StartOfDay.
+func DaySpan(first, last time.Time, loc *time.Location) (start, end time.Time) {
+	start = togo.StartOfDay(first, loc)
+	end = togo.StartOfDay(last, loc).AddDate(0, 0, 1)
+	return start, end
+}
+
+// Completion is the result of Store.CompleteTask and Store.TransitionTask: the
+// task in its new status and the next occurrence created when it was
+// completed, nil when the task doesn't recur again.
+type Completion struct {
+	Task togo.Task
+	Next *togo.Task
+}
+
+// NextOccurrence returns the occurrence of t to save once t has just been
+// completed at now. A task that had been completed before already has its next
+// occurrence, so it only follows the first completion.
+func NextOccurrence(t togo.Task, now time.Time) (togo.Task, bool) {
+	completions := 0
+	for _, transition := range t.Transitions {
+		if transition.To == togo.Completed {
+			completions++
+		}
+	}
+	if completions > 1 {
+		return togo.Task{}, false
+	}
+	return t.NextOccurrenceAt(now)
+}
+
+type actorKey struct{}
+
+// WithActor returns a context that attributes the changes of status made with
+// it to actor, such as the name of the user making a request.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// Actor returns the actor set on ctx by WithActor, empty when there is none.
+func Actor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// RemoveRule decides what happens to the subtasks of a removed task.
+type RemoveRule int
+
+const (
+	// OrphanSubtasks keeps the direct subtasks as top-level tasks.
+	OrphanSubtasks RemoveRule = iota
+	// RemoveSubtasks removes every subtask, at any depth, along with the task.
+	RemoveSubtasks
+	// RestrictSubtasks refuses to remove a task that has subtasks, reporting a
+	// conflict.
+	RestrictSubtasks
+)
+
+// Page selects a page of a listing. Limit caps the number of results, zero
+// selects DefaultPageLimit and anything over MaxPageLimit is reduced to it.
+// Cursor is empty for the first page and the Next cursor of the previous page
+// afterwards.
+type Page struct {
+	Limit  int
+	Cursor string
+}
+
+const (
+	DefaultPageLimit = 100
+	MaxPageLimit     = 1000
+)
+
+// Size returns the number of results to return for p.
+func (p Page) Size() int {
+	switch {
+	case p.Limit <= 0:
+		return DefaultPageLimit
+	case p.Limit > MaxPageLimit:
+		return MaxPageLimit
+	default:
+		return p.Limit
+	}
+}
+
+// TaskPage is a page of tasks. Next is empty on the last page.
+type TaskPage struct {
+	Tasks []togo.Task
+	Next  string
 }
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDaySpanFollowsDaylightSavingTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// clocks go forward on March 10th 2030, the day is 23 hours long
+	day := time.Date(2030, time.March, 10, 0, 0, 0, 0, time.UTC)
+	start, end := DaySpan(day, day, newYork)
+	if !start.Equal(time.Date(2030, time.March, 10, 5, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected start %v", start)
+	}
+	if length := end.Sub(start); length != 23*time.Hour {
+		t.Errorf("expected the day to last %v found %v", 23*time.Hour, length)
+	}
+
+	start, end = DaySpan(day, day, nil)
+	if !start.Equal(day) || !end.Equal(day.AddDate(0, 0, 1)) {
+		t.Errorf("expected a nil location to be UTC, found %v to %v", start, end)
+	}
+}
+
+func TestActorIsCarriedByTheContext(t *testing.T) {
+	ctx := context.Background()
+	if actor := Actor(ctx); actor != "" {
+		t.Errorf("expected no actor found %q", actor)
+	}
+	if actor := Actor(WithActor(ctx, "alice")); actor != "alice" {
+		t.Errorf("expected %q found %q", "alice", actor)
+	}
+}
@@ -0,0 +1,1937 @@
+// Package storetest provides a behavioral test suite that every store.Store
+// implementation must pass before it can be used interchangeably with the
+// existing backends.
+package storetest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jaswdr/faker"
+	"github.com/peschkaj/togo"
+	"github.com/peschkaj/togo/store"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Factory returns an empty store.Store that reads the current time from clock.
+// It is called once per test case and is responsible for registering any
+// cleanup with t.
+type Factory func(t *testing.T, clock togo.Clock) store.Store
+
+// clockStart is the time every test case starts at. It is mid-morning in UTC
+// so that the days around it don't depend on when the suite runs.
+var clockStart = time.Date(2030, time.March, 14, 9, 30, 0, 0, time.UTC)
+
+// Run executes the full conformance suite against stores created by newStore.
+func Run(t *testing.T, newStore Factory) {
+	tests := []struct {
+		name string
+		test func(*testing.T, store.Store, *togo.FakeClock)
+	}{
+		{"AddIncreasesCount", testAddIncreasesCount},
+		{"UpdateChangesTask", testUpdateChangesTask},
+		{"RenameKeepsIdentity", testRenameKeepsIdentity},
+		{"RemoveTask", testRemoveTask},
+		{"FindTask", testFindTask},
+		{"FindTasksByName", testFindTasksByName},
+		{"FindByDueDate", testFindByDueDate},
+		{"FindByNilDueDate", testFindByNilDueDate},
+		{"UpdateMovesDueDate", testUpdateMovesDueDate},
+		{"FindDueBetween", testFindDueBetween},
+		{"OverdueTasks", testOverdueTasks},
+		{"OverdueTasksDueAtTime", testOverdueTasksDueAtTime},
+		{"OverdueTasksFollowTheClock", testOverdueTasksFollowTheClock},
+		{"DueZoneRoundTrip", testDueZoneRoundTrip},
+		{"FindByDueDateInLocation", testFindByDueDateInLocation},
+		{"InvalidDueZone", testInvalidDueZone},
+		{"Upcoming", testUpcoming},
+		{"UpcomingFollowsTheClock", testUpcomingFollowsTheClock},
+		{"All", testAll},
+		{"ListTasksPages", testListTasksPages},
+		{"ListTasksAfterRemovedCursor", testListTasksAfterRemovedCursor},
+		{"ListTasksInvalidCursor", testListTasksInvalidCursor},
+		{"QueryTasks", testQueryTasks},
+		{"QueryTasksSort", testQueryTasksSort},
+		{"InvalidQuery", testInvalidQuery},
+		{"Subtasks", testSubtasks},
+		{"SubtaskParentMustExist", testSubtaskParentMustExist},
+		{"MoveTask", testMoveTask},
+		{"SubtasksCannotLoop", testSubtasksCannotLoop},
+		{"RemoveOrphansSubtasks", testRemoveOrphansSubtasks},
+		{"RemoveSubtasks", testRemoveSubtasks},
+		{"RestrictSubtasks", testRestrictSubtasks},
+		{"CompleteTask", testCompleteTask},
+		{"CompleteRecurringTask", testCompleteRecurringTask},
+		{"InvalidRecurrence", testInvalidRecurrence},
+		{"TransitionTask", testTransitionTask},
+		{"ReopenTask", testReopenTask},
+		{"ReopenRecurringTask", testReopenRecurringTask},
+		{"StatusRoundTrip", testStatusRoundTrip},
+		{"InvalidStatus", testInvalidStatus},
+		{"StatusChangesNeedTransitions", testStatusChangesNeedTransitions},
+		{"SavingKeepsTheHistory", testSavingKeepsTheHistory},
+		{"QueryTasksByStatus", testQueryTasksByStatus},
+		{"FindMissingTask", testFindMissingTask},
+		{"RemoveMissingTask", testRemoveMissingTask},
+		{"InvalidTask", testInvalidTask},
+		{"ProjectRoundTrip", testProjectRoundTrip},
+		{"ProjectNamesAreUnique", testProjectNamesAreUnique},
+		{"InvalidProject", testInvalidProject},
+		{"MissingProject", testMissingProject},
+		{"TasksInProject", testTasksInProject},
+		{"TaskInUnknownProject", testTaskInUnknownProject},
+		{"RemoveProjectKeepsTasks", testRemoveProjectKeepsTasks},
+		{"CanceledContext", testCanceledContext},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			clock := togo.NewFakeClock(clockStart)
+			tc.test(t, newStore(t, clock), clock)
+		})
+	}
+}
+
+// daysFromNow counts from clockStart, in UTC, the location used by lookups by
+// day without one.
+func daysFromNow(days int) time.Time {
+	return clockStart.AddDate(0, 0, days)
+}
+
+func newTask(f faker.Faker) togo.Task {
+	return togo.NewTask(f.UUID().V4(), f.Lorem().Paragraph(3))
+}
+
+func mustAdd(t *testing.T, s store.Store, tasks ...togo.Task) {
+	t.Helper()
+	for _, task := range tasks {
+		if err := s.AddOrUpdateTask(context.Background(), task); err != nil {
+			t.Fatalf("unable to add task %q: %v", task.Name, err)
+		}
+	}
+}
+
+func newProject(f faker.Faker) togo.Project {
+	return togo.NewProject(f.UUID().V4(), f.Lorem().Sentence(5))
+}
+
+func mustAddProject(t *testing.T, s store.Store, projects ...togo.Project) {
+	t.Helper()
+	for _, project := range projects {
+		if err := s.AddOrUpdateProject(context.Background(), project); err != nil {
+			t.Fatalf("unable to add project %q: %v", project.Name, err)
+		}
+	}
+}
+
+func mustCount(t *testing.T, s store.Store) int {
+	t.Helper()
+	count, err := s.Count(context.Background())
+	if err != nil {
+		t.Fatalf("unable to count tasks: %v", err)
+	}
+	return count
+}
+
+func names(tasks []togo.Task) []string {
+	ns := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ns = append(ns, task.Name)
+	}
+	sort.Strings(ns)
+	return ns
+}
+
+func expectNames(t *testing.T, got []togo.Task, want ...togo.Task) {
+	t.Helper()
+	gotNames, wantNames := names(got), names(want)
+	if len(gotNames) != len(wantNames) {
+		t.Fatalf("expected %d tasks found %d", len(wantNames), len(gotNames))
+	}
+	for i := range gotNames {
+		if gotNames[i] != wantNames[i] {
+			t.Fatalf("expected tasks %v found %v", wantNames, gotNames)
+		}
+	}
+}
+
+// expectOrder checks that got holds the same tasks as want, in the same order.
+func expectOrder(t *testing.T, got []togo.Task, want ...togo.Task) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d tasks found %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID {
+			t.Fatalf("expected %q at position %d found %q", want[i].Name, i, got[i].Name)
+		}
+	}
+}
+
+func testAddIncreasesCount(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	f := faker.New()
+
+	if count := mustCount(t, s); count != 0 {
+		t.Fatalf("store is not empty after initialization, found %d tasks", count)
+	}
+
+	for i := 1; i <= 3; i++ {
+		mustAdd(t, s, newTask(f))
+		if count := mustCount(t, s); count != i {
+			t.Errorf("expected %d tasks found %d", i, count)
+		}
+	}
+}
+
+func testUpdateChangesTask(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	task := newTask(f)
+	mustAdd(t, s, task)
+
+	task.Description = f.Lorem().Paragraph(2)
+	task.Priority = togo.High
+	task.AddDueDate(daysFromNow(1))
+	mustAdd(t, s, task)
+
+	if count := mustCount(t, s); count != 1 {
+		t.Errorf("update created a new task, found %d tasks", count)
+	}
+
+	updated, err := s.FindTask(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Description != task.Description {
+		t.Error("description was not updated")
+	}
+	if updated.Priority != task.Priority {
+		t.Error("priority was not updated")
+	}
+	if updated.DueOn() == nil || !updated.DueOn().Equal(*task.DueOn()) {
+		t.Error("due date was not updated")
+	}
+}
+
+func testRemoveTask(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	task := newTask(f)
+	mustAdd(t, s, task, newTask(f), newTask(f))
+
+	if err := s.RemoveTask(ctx, task.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := mustCount(t, s); count != 2 {
+		t.Errorf("expected %d tasks found %d", 2, count)
+	}
+
+	all, err := s.All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, other := range all {
+		if other.ID == task.ID {
+			t.Error("removed task is still in the store")
+		}
+	}
+}
+
+func testRenameKeepsIdentity(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	task := newTask(f)
+	originalName := task.Name
+	mustAdd(t, s, task)
+
+	task.Name = f.UUID().V4()
+	mustAdd(t, s, task)
+
+	if count := mustCount(t, s); count != 1 {
+		t.Errorf("renaming created a new task, found %d tasks", count)
+	}
+
+	found, err := s.FindTasksByName(ctx, originalName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found)
+
+	found, err = s.FindTasksByName(ctx, task.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found, task)
+}
+
+func testFindTask(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	task := newTask(f)
+	task.Priority = togo.Medium
+	task.AddDueDate(daysFromNow(3))
+	// several other tasks ensure we're not testing a degenerate case
+	mustAdd(t, s, newTask(f), task, newTask(f))
+
+	found, err := s.FindTask(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.ID != task.ID || found.Name != task.Name || found.Description != task.Description {
+		t.Error("found task wasn't the same as original task")
+	}
+	if found.Priority != task.Priority {
+		t.Errorf("expected priority %d found %d", task.Priority, found.Priority)
+	}
+	if !found.Created.Truncate(time.Millisecond).Equal(task.Created.Truncate(time.Millisecond)) {
+		t.Error("creation dates do not match")
+	}
+	if found.DueOn() == nil || !found.DueOn().Equal(*task.DueOn()) {
+		t.Error("due dates do not match")
+	}
+}
+
+func testFindTasksByName(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	first := newTask(f)
+	second := togo.NewTask(first.Name, f.Lorem().Paragraph(1))
+	mustAdd(t, s, first, second, newTask(f))
+
+	found, err := s.FindTasksByName(ctx, first.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 2 {
+		t.Fatalf("expected %d tasks found %d", 2, len(found))
+	}
+	if found[0].ID == found[1].ID {
+		t.Error("tasks sharing a name were merged")
+	}
+
+	found, err = s.FindTasksByName(ctx, f.UUID().V4())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found)
+}
+
+func testFindByDueDate(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+	today := daysFromNow(0)
+
+	var dueToday []togo.Task
+	for i := 0; i < 3; i++ {
+		task := newTask(f)
+		task.AddDueDate(today)
+		dueToday = append(dueToday, task)
+	}
+	mustAdd(t, s, dueToday...)
+
+	tomorrow := newTask(f)
+	tomorrow.AddDueDate(daysFromNow(1))
+	yesterday := newTask(f)
+	yesterday.AddDueDate(daysFromNow(-1))
+	mustAdd(t, s, tomorrow, yesterday, newTask(f))
+
+	found, err := s.FindByDueDate(ctx, dueToday[0].DueOn(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found, dueToday...)
+}
+
+func testFindByNilDueDate(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	undated := newTask(f)
+	dated := newTask(f)
+	dated.AddDueDate(daysFromNow(2))
+	mustAdd(t, s, undated, dated)
+
+	found, err := s.FindByDueDate(ctx, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found, undated)
+}
+
+func testUpdateMovesDueDate(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	task := newTask(f)
+	mustAdd(t, s, task)
+
+	task.AddDueDate(daysFromNow(1))
+	mustAdd(t, s, task)
+	oldDueDate := *task.DueOn()
+
+	task.AddDueDate(daysFromNow(3))
+	mustAdd(t, s, task)
+
+	for _, stale := range []*time.Time{nil, &oldDueDate} {
+		found, err := s.FindByDueDate(ctx, stale, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectNames(t, found)
+	}
+
+	found, err := s.FindByDueDate(ctx, task.DueOn(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found, task)
+}
+
+func testFindDueBetween(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	var inRange []togo.Task
+	// added out of order so the result order has to come from the store
+	for _, days := range []int{3, -1, 1, 0} {
+		task := newTask(f)
+		task.AddDueDate(daysFromNow(days))
+		inRange = append(inRange, task)
+	}
+	mustAdd(t, s, inRange...)
+
+	for _, days := range []int{-2, 4, 30} {
+		task := newTask(f)
+		task.AddDueDate(daysFromNow(days))
+		mustAdd(t, s, task)
+	}
+	mustAdd(t, s, newTask(f))
+
+	found, err := s.FindDueBetween(ctx, *inRange[1].DueOn(), *inRange[0].DueOn(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found, inRange...)
+
+	for i := 1; i < len(found); i++ {
+		if found[i].DueOn().Before(*found[i-1].DueOn()) {
+			t.Fatal("tasks are not ordered by due date")
+		}
+	}
+}
+
+func testOverdueTasks(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	var overdue []togo.Task
+	for _, days := range []int{-7, -2, -1} {
+		task := newTask(f)
+		task.AddDueDate(daysFromNow(days))
+		overdue = append(overdue, task)
+	}
+	mustAdd(t, s, overdue...)
+
+	for _, days := range []int{1, 2, 7} {
+		task := newTask(f)
+		task.AddDueDate(daysFromNow(days))
+		mustAdd(t, s, task)
+	}
+	mustAdd(t, s, newTask(f))
+
+	found, err := s.OverdueTasks(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found, overdue...)
+}
+
+func testOverdueTasksDueAtTime(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+	now := clock.Now()
+
+	anHourAgo := newTask(f)
+	anHourAgo.AddDueTime(now.Add(-time.Hour))
+	inAnHour := newTask(f)
+	inAnHour.AddDueTime(now.Add(time.Hour))
+	// due today, but not overdue until the day is over
+	today := newTask(f)
+	today.AddDueDate(now)
+	mustAdd(t, s, anHourAgo, inAnHour, today)
+
+	found, err := s.OverdueTasks(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found, anHourAgo)
+}
+
+func testOverdueTasksFollowTheClock(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+	kiritimati := mustLoadZone(t, "Pacific/Kiritimati")
+
+	dueToday := newTask(f)
+	dueToday.AddDueDate(clockStart)
+	dueAtNoon := newTask(f)
+	dueAtNoon.AddDueTime(time.Date(2030, time.March, 14, 12, 0, 0, 0, time.UTC))
+	mustAdd(t, s, dueToday, dueAtNoon)
+
+	testCases := []struct {
+		now  time.Time
+		loc  *time.Location
+		want []togo.Task
+	}{
+		{clockStart, nil, nil},
+		{time.Date(2030, time.March, 14, 9, 59, 0, 0, time.UTC), kiritimati, nil},
+		// Kiritimati is fourteen hours ahead, the 14th is over there at 10:00
+		{time.Date(2030, time.March, 14, 10, 0, 0, 0, time.UTC), kiritimati, []togo.Task{dueToday}},
+		{time.Date(2030, time.March, 14, 12, 0, 0, 0, time.UTC), nil, nil},
+		{time.Date(2030, time.March, 14, 12, 1, 0, 0, time.UTC), nil, []togo.Task{dueAtNoon}},
+		{time.Date(2030, time.March, 14, 23, 59, 0, 0, time.UTC), nil, []togo.Task{dueAtNoon}},
+		{time.Date(2030, time.March, 15, 0, 0, 0, 0, time.UTC), nil, []togo.Task{dueToday, dueAtNoon}},
+	}
+
+	for _, testCase := range testCases {
+		clock.Set(testCase.now)
+		found, err := s.OverdueTasks(ctx, testCase.loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectNames(t, found, testCase.want...)
+	}
+}
+
+func mustLoadZone(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := togo.LoadZone(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func testDueZoneRoundTrip(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	f := faker.New()
+
+	onDay := newTask(f)
+	onDay.AddDueDate(time.Date(2030, time.January, 8, 0, 0, 0, 0, mustLoadZone(t, "Asia/Tokyo")))
+	// daylight saving time starts in New York on this day
+	atTime := newTask(f)
+	atTime.AddDueTime(time.Date(2030, time.March, 10, 9, 30, 0, 0, mustLoadZone(t, "America/New_York")))
+	mustAdd(t, s, onDay, atTime)
+
+	for _, task := range []togo.Task{onDay, atTime} {
+		found := mustFind(t, s, task.ID)
+		if found.DueDate == nil || !found.DueDate.Equal(*task.DueDate) {
+			t.Errorf("expected %q to be due at %v found %v", task.Name, task.DueDate, found.DueDate)
+			continue
+		}
+		if zone := found.DueZone().String(); zone != task.DueZone().String() {
+			t.Errorf("expected %q to be due in %s found %s", task.Name, task.DueZone(), zone)
+		}
+		if found.DueDate.Hour() != task.DueDate.Hour() || found.HasDueTime != task.HasDueTime {
+			t.Errorf("expected %q to be due at %v found %v", task.Name, task.DueDate, found.DueDate)
+		}
+	}
+}
+
+func testFindByDueDateInLocation(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+	tokyo := mustLoadZone(t, "Asia/Tokyo")
+	losAngeles := mustLoadZone(t, "America/Los_Angeles")
+
+	// 2030-01-07 15:00 UTC
+	tokyoDay := newTask(f)
+	tokyoDay.AddDueDate(time.Date(2030, time.January, 8, 0, 0, 0, 0, tokyo))
+	// 2030-01-08 00:00 UTC
+	utcDay := newTask(f)
+	utcDay.AddDueDate(time.Date(2030, time.January, 8, 0, 0, 0, 0, time.UTC))
+	// 2030-01-08 07:30 UTC
+	losAngelesEvening := newTask(f)
+	losAngelesEvening.AddDueTime(time.Date(2030, time.January, 7, 23, 30, 0, 0, losAngeles))
+	mustAdd(t, s, tokyoDay, utcDay, losAngelesEvening, newTask(f))
+
+	jan7 := time.Date(2030, time.January, 7, 0, 0, 0, 0, time.UTC)
+	jan8 := time.Date(2030, time.January, 8, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		day      time.Time
+		loc      *time.Location
+		expected []togo.Task
+	}{
+		{"8th in Tokyo", jan8, tokyo, []togo.Task{tokyoDay, utcDay, losAngelesEvening}},
+		{"8th in UTC", jan8, nil, []togo.Task{utcDay, losAngelesEvening}},
+		{"7th in UTC", jan7, nil, []togo.Task{tokyoDay}},
+		{"7th in Los Angeles", jan7, losAngeles, []togo.Task{tokyoDay, utcDay, losAngelesEvening}},
+		{"8th in Los Angeles", jan8, losAngeles, nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			found, err := s.FindByDueDate(ctx, &testCase.day, testCase.loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectNames(t, found, testCase.expected...)
+
+			found, err = s.FindDueBetween(ctx, testCase.day, testCase.day, testCase.loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectNames(t, found, testCase.expected...)
+
+			found, err = s.QueryTasks(ctx, store.Query{}.WhereDue(testCase.day, testCase.day).InLocation(testCase.loc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectNames(t, found, testCase.expected...)
+		})
+	}
+}
+
+func testInvalidDueZone(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	unnamed := newTask(f)
+	unnamed.AddDueDate(time.Date(2030, time.January, 8, 0, 0, 0, 0, time.FixedZone("", 60*60)))
+	// AddDueDate moves a Local date to UTC, so set it directly
+	local := newTask(f)
+	localDate := time.Date(2030, time.January, 8, 0, 0, 0, 0, time.Local)
+	local.DueDate = &localDate
+	timeWithoutDate := newTask(f)
+	timeWithoutDate.HasDueTime = true
+
+	for _, task := range []togo.Task{unnamed, local, timeWithoutDate} {
+		if err := s.AddOrUpdateTask(ctx, task); !errors.Is(err, store.ErrInvalid) {
+			t.Errorf("expected %v found %v", store.ErrInvalid, err)
+		}
+	}
+	if count := mustCount(t, s); count != 0 {
+		t.Errorf("invalid tasks were saved, found %d tasks", count)
+	}
+}
+
+func testUpcoming(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	dueIn := func(days int, priority togo.Priority) togo.Task {
+		task := newTask(f)
+		task.Priority = priority
+		task.AddDueDate(daysFromNow(days))
+		return task
+	}
+
+	today := dueIn(0, togo.None)
+	tomorrowLow := dueIn(1, togo.Low)
+	tomorrowHigh := dueIn(1, togo.High)
+	completed := dueIn(2, togo.Medium)
+	completed.Complete()
+	cancelled := dueIn(2, togo.High)
+	mustAdd(t, s, tomorrowLow, completed, today, tomorrowHigh, cancelled)
+	if _, err := s.TransitionTask(ctx, cancelled.ID, togo.Cancelled); err != nil {
+		t.Fatal(err)
+	}
+
+	// outside of the window
+	mustAdd(t, s, dueIn(-1, togo.High), dueIn(5, togo.High), newTask(f))
+
+	found, err := s.Upcoming(ctx, store.UpcomingWindow{Days: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectOrder(t, found, today, tomorrowHigh, tomorrowLow)
+
+	found, err = s.Upcoming(ctx, store.UpcomingWindow{Days: 3, IncludeCompleted: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectOrder(t, found, today, tomorrowHigh, tomorrowLow, cancelled, completed)
+
+	found, err = s.Upcoming(ctx, store.UpcomingWindow{Days: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectOrder(t, found, today)
+}
+
+func testUpcomingFollowsTheClock(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+	kiritimati := mustLoadZone(t, "Pacific/Kiritimati")
+
+	today := newTask(f)
+	today.AddDueDate(daysFromNow(0))
+	tomorrow := newTask(f)
+	tomorrow.AddDueDate(daysFromNow(1))
+	mustAdd(t, s, today, tomorrow)
+
+	testCases := []struct {
+		now  time.Time
+		loc  *time.Location
+		want []togo.Task
+	}{
+		{clockStart, nil, []togo.Task{today}},
+		{time.Date(2030, time.March, 14, 23, 59, 0, 0, time.UTC), nil, []togo.Task{today}},
+		{time.Date(2030, time.March, 15, 0, 0, 0, 0, time.UTC), nil, []togo.Task{tomorrow}},
+		{time.Date(2030, time.March, 14, 9, 59, 0, 0, time.UTC), kiritimati, []togo.Task{today}},
+		// Kiritimati is fourteen hours ahead, the 15th starts there at 10:00
+		{time.Date(2030, time.March, 14, 10, 0, 0, 0, time.UTC), kiritimati, []togo.Task{tomorrow}},
+	}
+
+	for _, testCase := range testCases {
+		clock.Set(testCase.now)
+		found, err := s.Upcoming(ctx, store.UpcomingWindow{Days: 0, Location: testCase.loc})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectOrder(t, found, testCase.want...)
+	}
+}
+
+func testAll(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	var tasks []togo.Task
+	for i := 0; i < 5; i++ {
+		task := newTask(f)
+		if i%2 == 0 {
+			task.AddDueDate(daysFromNow(i - 2))
+		}
+		tasks = append(tasks, task)
+	}
+	mustAdd(t, s, tasks...)
+
+	all, err := s.All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, all, tasks...)
+}
+
+func testListTasksPages(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	var tasks []togo.Task
+	for i := 0; i < 7; i++ {
+		tasks = append(tasks, newTask(f))
+	}
+	mustAdd(t, s, tasks...)
+
+	var (
+		listed []togo.Task
+		sizes  []int
+		page   = store.Page{Limit: 3}
+	)
+	for {
+		result, err := s.ListTasks(ctx, page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed = append(listed, result.Tasks...)
+		sizes = append(sizes, len(result.Tasks))
+		if result.Next == "" {
+			break
+		}
+		if len(sizes) > len(tasks) {
+			t.Fatal("listing did not end")
+		}
+		page.Cursor = result.Next
+	}
+
+	if fmt.Sprint(sizes) != fmt.Sprint([]int{3, 3, 1}) {
+		t.Errorf("expected pages of %v found %v", []int{3, 3, 1}, sizes)
+	}
+	expectNames(t, listed, tasks...)
+	for i := 1; i < len(listed); i++ {
+		if bytes.Compare(listed[i-1].ID[:], listed[i].ID[:]) >= 0 {
+			t.Errorf("tasks are not ordered by ID at %d", i)
+		}
+	}
+
+	whole, err := s.ListTasks(ctx, store.Page{Limit: len(tasks)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(whole.Tasks) != len(tasks) || whole.Next != "" {
+		t.Errorf("expected a single page of %d tasks, found %d tasks and next %q", len(tasks), len(whole.Tasks), whole.Next)
+	}
+}
+
+func testListTasksAfterRemovedCursor(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	var tasks []togo.Task
+	for i := 0; i < 4; i++ {
+		tasks = append(tasks, newTask(f))
+	}
+	mustAdd(t, s, tasks...)
+
+	first, err := s.ListTasks(ctx, store.Page{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RemoveTask(ctx, first.Tasks[1].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := s.ListTasks(ctx, store.Page{Limit: 2, Cursor: first.Next})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second.Tasks) != 2 {
+		t.Fatalf("expected %d tasks after the removed cursor found %d", 2, len(second.Tasks))
+	}
+	for _, task := range second.Tasks {
+		if task.ID == first.Tasks[0].ID || task.ID == first.Tasks[1].ID {
+			t.Errorf("task %q was listed twice", task.Name)
+		}
+	}
+}
+
+func testListTasksInvalidCursor(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	mustAdd(t, s, newTask(faker.New()))
+
+	if _, err := s.ListTasks(ctx, store.Page{Cursor: "not a cursor"}); !errors.Is(err, store.ErrInvalid) {
+		t.Errorf("expected %v found %v", store.ErrInvalid, err)
+	}
+}
+
+func testQueryTasks(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	project := newProject(f)
+	mustAddProject(t, s, project)
+
+	newNamedTask := func(name, description string, priority togo.Priority) togo.Task {
+		task := togo.NewTask(name, description)
+		task.Priority = priority
+		return task
+	}
+
+	plants := newNamedTask("Water the plants", "", togo.Low)
+	plants.AddDueDate(daysFromNow(1))
+	plants.AddToProject(project)
+	bank := newNamedTask("Call the bank", "", togo.High)
+	bank.AddDueDate(daysFromNow(3))
+	milk := newNamedTask("Buy milk", "", togo.Medium)
+	milk.AddDueDate(daysFromNow(-2))
+	milk.Complete()
+	trip := newNamedTask("Book a trip", "Pick PLANTS for the patio", togo.None)
+	passport := newNamedTask("Renew passport", "", togo.None)
+	passport.Created = daysFromNow(-30)
+	quiz := newNamedTask("Score 100% on the quiz", "", togo.None)
+	pushUps := newNamedTask("Do 1000 push-ups", "", togo.None)
+	mustAdd(t, s, plants, bank, milk, trip, passport, quiz, pushUps)
+
+	today := clock.Now()
+	testCases := []struct {
+		name  string
+		query store.Query
+		want  []togo.Task
+	}{
+		{"everything", store.Query{}, []togo.Task{plants, bank, milk, trip, passport, quiz, pushUps}},
+		{"completed", store.Query{}.WhereCompleted(true), []togo.Task{milk}},
+		{"open", store.Query{}.WhereCompleted(false), []togo.Task{plants, bank, trip, passport, quiz, pushUps}},
+		{"priority", store.Query{}.WherePriority(togo.Medium, togo.High), []togo.Task{bank, milk}},
+		{"minimum priority", store.Query{MinPriority: &bank.Priority}, []togo.Task{bank}},
+		{"due", store.Query{}.WhereDue(today, daysFromNow(3)), []togo.Task{plants, bank}},
+		{"due from", store.Query{DueFrom: &today}, []togo.Task{plants, bank}},
+		{"due to", store.Query{DueTo: &today}, []togo.Task{milk}},
+		{"created", store.Query{}.WhereCreated(daysFromNow(-40), daysFromNow(-20)), []togo.Task{passport}},
+		{"project", store.Query{}.WhereProject(project.ID), []togo.Task{plants}},
+		{"unknown project", store.Query{}.WhereProject(uuid.New()), nil},
+		{"text", store.Query{}.WhereText("plant"), []togo.Task{plants, trip}},
+		{"wildcards are literal", store.Query{}.WhereText("0%"), []togo.Task{quiz}},
+		{"combined", store.Query{}.WhereCompleted(false).WherePriority(togo.Low, togo.High).WhereDue(daysFromNow(-7), daysFromNow(7)), []togo.Task{plants, bank}},
+	}
+
+	for _, testCase := range testCases {
+		found, err := s.QueryTasks(ctx, testCase.query)
+		if err != nil {
+			t.Fatalf("%s: %v", testCase.name, err)
+		}
+		if !reflect.DeepEqual(names(found), names(testCase.want)) {
+			t.Errorf("%s: expected %v found %v", testCase.name, names(testCase.want), names(found))
+		}
+	}
+}
+
+func testQueryTasksSort(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+
+	newSortedTask := func(name string, priority togo.Priority, dueIn *int) togo.Task {
+		task := togo.NewTask(name, "")
+		task.Priority = priority
+		if dueIn != nil {
+			task.AddDueDate(daysFromNow(*dueIn))
+		}
+		return task
+	}
+	one, two, three := 1, 2, 3
+
+	b := newSortedTask("b", togo.High, &two)
+	a := newSortedTask("a", togo.Low, &one)
+	c := newSortedTask("c", togo.High, nil)
+	d := newSortedTask("d", togo.High, &three)
+	mustAdd(t, s, d, c, b, a)
+
+	byID := []togo.Task{a, b, c, d}
+	sort.Slice(byID, func(i, j int) bool { return byID[i].ID.String() < byID[j].ID.String() })
+
+	testCases := []struct {
+		name string
+		sort []store.SortKey
+		want []togo.Task
+	}{
+		{"unsorted", nil, byID},
+		{"title", []store.SortKey{store.Asc(store.SortByTitle)}, []togo.Task{a, b, c, d}},
+		{"title descending", []store.SortKey{store.Desc(store.SortByTitle)}, []togo.Task{d, c, b, a}},
+		{"due date", []store.SortKey{store.Asc(store.SortByDueDate)}, []togo.Task{a, b, d, c}},
+		{"due date descending", []store.SortKey{store.Desc(store.SortByDueDate)}, []togo.Task{d, b, a, c}},
+		{"priority and due date", []store.SortKey{store.Desc(store.SortByPriority), store.Asc(store.SortByDueDate)}, []togo.Task{b, d, c, a}},
+	}
+
+	for _, testCase := range testCases {
+		found, err := s.QueryTasks(ctx, store.Query{}.OrderBy(testCase.sort...))
+		if err != nil {
+			t.Fatalf("%s: %v", testCase.name, err)
+		}
+		t.Run(testCase.name, func(t *testing.T) {
+			expectOrder(t, found, testCase.want...)
+		})
+	}
+}
+
+func testInvalidQuery(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	unknown := togo.Priority(7)
+
+	for _, q := range []store.Query{
+		{MinPriority: &unknown},
+		{MaxPriority: &unknown},
+		store.Query{}.OrderBy(store.SortKey{Field: 99}),
+	} {
+		if _, err := s.QueryTasks(ctx, q); !errors.Is(err, store.ErrInvalid) {
+			t.Errorf("%+v: expected %v found %v", q, store.ErrInvalid, err)
+		}
+	}
+}
+
+// newHierarchy saves a parent task with two subtasks, the first of which has a
+// subtask of its own.
+func newHierarchy(t *testing.T, s store.Store) (parent, child, sibling, grandchild togo.Task) {
+	t.Helper()
+	f := faker.New()
+
+	parent = newTask(f)
+	child = newTask(f)
+	child.AddToParent(parent)
+	sibling = newTask(f)
+	sibling.AddToParent(parent)
+	grandchild = newTask(f)
+	grandchild.AddToParent(child)
+	mustAdd(t, s, parent, child, sibling, grandchild)
+	return parent, child, sibling, grandchild
+}
+
+func mustFind(t *testing.T, s store.Store, id uuid.UUID) togo.Task {
+	t.Helper()
+	task, err := s.FindTask(context.Background(), id)
+	if err != nil {
+		t.Fatalf("unable to find task %v: %v", id, err)
+	}
+	return task
+}
+
+func testSubtasks(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	parent, child, sibling, grandchild := newHierarchy(t, s)
+
+	found, err := s.Subtasks(ctx, parent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found, child, sibling)
+	for _, task := range found {
+		if !task.IsSubtaskOf(parent) {
+			t.Errorf("task %q does not reference its parent", task.Name)
+		}
+	}
+
+	found, err = s.Subtasks(ctx, child.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found, grandchild)
+
+	found, err = s.Subtasks(ctx, grandchild.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found)
+
+	if _, err := s.Subtasks(ctx, uuid.New()); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected %v found %v", store.ErrNotFound, err)
+	}
+}
+
+func testSubtaskParentMustExist(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	orphan := newTask(f)
+	missing := uuid.New()
+	orphan.ParentID = &missing
+	if err := s.AddOrUpdateTask(ctx, orphan); !errors.Is(err, store.ErrInvalid) {
+		t.Errorf("expected %v found %v", store.ErrInvalid, err)
+	}
+
+	own := newTask(f)
+	own.AddToParent(own)
+	if err := s.AddOrUpdateTask(ctx, own); !errors.Is(err, store.ErrInvalid) {
+		t.Errorf("expected %v found %v", store.ErrInvalid, err)
+	}
+
+	if count := mustCount(t, s); count != 0 {
+		t.Errorf("expected %d tasks found %d", 0, count)
+	}
+}
+
+func testMoveTask(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	parent, child, sibling, grandchild := newHierarchy(t, s)
+
+	if err := s.MoveTask(ctx, grandchild.ID, &sibling.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.MoveTask(ctx, child.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := s.Subtasks(ctx, parent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found, sibling)
+
+	found, err = s.Subtasks(ctx, sibling.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found, grandchild)
+
+	if moved := mustFind(t, s, child.ID); moved.ParentID != nil {
+		t.Errorf("expected a top-level task found parent %v", *moved.ParentID)
+	}
+
+	if err := s.MoveTask(ctx, uuid.New(), &parent.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected %v found %v", store.ErrNotFound, err)
+	}
+	missing := uuid.New()
+	if err := s.MoveTask(ctx, child.ID, &missing); !errors.Is(err, store.ErrInvalid) {
+		t.Errorf("expected %v found %v", store.ErrInvalid, err)
+	}
+}
+
+func testSubtasksCannotLoop(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	parent, child, _, grandchild := newHierarchy(t, s)
+
+	for _, under := range []togo.Task{parent, child, grandchild} {
+		if err := s.MoveTask(ctx, parent.ID, &under.ID); !errors.Is(err, store.ErrInvalid) {
+			t.Errorf("moving under %q: expected %v found %v", under.Name, store.ErrInvalid, err)
+		}
+	}
+
+	parent.AddToParent(grandchild)
+	if err := s.AddOrUpdateTask(ctx, parent); !errors.Is(err, store.ErrInvalid) {
+		t.Errorf("expected %v found %v", store.ErrInvalid, err)
+	}
+
+	if unchanged := mustFind(t, s, parent.ID); unchanged.ParentID != nil {
+		t.Errorf("expected a top-level task found parent %v", *unchanged.ParentID)
+	}
+}
+
+func testRemoveOrphansSubtasks(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	parent, child, sibling, grandchild := newHierarchy(t, s)
+
+	if err := s.RemoveTask(ctx, parent.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, task := range []togo.Task{child, sibling} {
+		if orphan := mustFind(t, s, task.ID); orphan.ParentID != nil {
+			t.Errorf("expected %q to be a top-level task found parent %v", task.Name, *orphan.ParentID)
+		}
+	}
+	if kept := mustFind(t, s, grandchild.ID); !kept.IsSubtaskOf(child) {
+		t.Errorf("expected %q to stay a subtask of %q", grandchild.Name, child.Name)
+	}
+	if err := s.RemoveTaskWith(ctx, child.ID, store.OrphanSubtasks); err != nil {
+		t.Fatal(err)
+	}
+	if orphan := mustFind(t, s, grandchild.ID); orphan.ParentID != nil {
+		t.Errorf("expected a top-level task found parent %v", *orphan.ParentID)
+	}
+}
+
+func testRemoveSubtasks(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+	parent, _, _, _ := newHierarchy(t, s)
+	unrelated := newTask(f)
+	mustAdd(t, s, unrelated)
+
+	if err := s.RemoveTaskWith(ctx, parent.ID, store.RemoveSubtasks); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := s.All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, all, unrelated)
+
+	if err := s.RemoveTaskWith(ctx, parent.ID, store.RemoveSubtasks); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected %v found %v", store.ErrNotFound, err)
+	}
+}
+
+func testRestrictSubtasks(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	parent, child, sibling, grandchild := newHierarchy(t, s)
+
+	for _, task := range []togo.Task{parent, child} {
+		if err := s.RemoveTaskWith(ctx, task.ID, store.RestrictSubtasks); !errors.Is(err, store.ErrConflict) {
+			t.Errorf("%q: expected %v found %v", task.Name, store.ErrConflict, err)
+		}
+	}
+	if count := mustCount(t, s); count != 4 {
+		t.Errorf("expected %d tasks found %d", 4, count)
+	}
+
+	for _, task := range []togo.Task{grandchild, sibling, child, parent} {
+		if err := s.RemoveTaskWith(ctx, task.ID, store.RestrictSubtasks); err != nil {
+			t.Errorf("%q: %v", task.Name, err)
+		}
+	}
+	if err := s.RemoveTaskWith(ctx, parent.ID, store.RestrictSubtasks); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected %v found %v", store.ErrNotFound, err)
+	}
+	if err := s.RemoveTaskWith(ctx, parent.ID, store.RemoveRule(-1)); !errors.Is(err, store.ErrInvalid) {
+		t.Errorf("expected %v found %v", store.ErrInvalid, err)
+	}
+}
+
+func testCompleteTask(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+	task := newTask(f)
+	mustAdd(t, s, task)
+
+	completion, err := s.CompleteTask(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if completion.Task.ID != task.ID || completion.Task.Completed == nil {
+		t.Fatalf("expected task %q to be completed", task.Name)
+	}
+	if completion.Next != nil {
+		t.Errorf("a one-off task should not repeat, found %q", completion.Next.Name)
+	}
+	completed := *completion.Task.Completed
+	if !completed.Equal(clock.Now()) {
+		t.Errorf("expected the task to be completed at %v, found %v", clock.Now(), completed)
+	}
+
+	clock.Advance(time.Hour)
+	again, err := s.CompleteTask(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Task.Completed == nil || !again.Task.Completed.Equal(completed) {
+		t.Errorf("expected the completion time %v to be kept, found %v", completed, again.Task.Completed)
+	}
+
+	found, err := s.FindTask(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Completed == nil || !found.Completed.Equal(completed) {
+		t.Errorf("expected the task to be completed at %v, found %v", completed, found.Completed)
+	}
+	if count := mustCount(t, s); count != 1 {
+		t.Errorf("expected %d tasks found %d", 1, count)
+	}
+
+	if _, err := s.CompleteTask(ctx, uuid.New()); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected %v found %v", store.ErrNotFound, err)
+	}
+}
+
+func testCompleteRecurringTask(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	project := newProject(f)
+	mustAddProject(t, s, project)
+
+	// every other Monday and Thursday, three times
+	recurrence, err := togo.ParseRecurrence("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := newTask(f)
+	task.Priority = togo.High
+	task.AddToProject(project)
+	task.AddDueDate(time.Date(2030, time.January, 7, 0, 0, 0, 0, time.UTC))
+	task.Recurrence = &recurrence
+	mustAdd(t, s, task)
+
+	found, err := s.FindTask(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Recurrence == nil || found.Recurrence.String() != recurrence.String() {
+		t.Fatalf("expected recurrence %v found %v", recurrence, found.Recurrence)
+	}
+
+	expected := []time.Time{
+		time.Date(2030, time.January, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2030, time.January, 21, 0, 0, 0, 0, time.UTC),
+	}
+	current := task
+	for i, due := range expected {
+		completion, err := s.CompleteTask(ctx, current.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if completion.Next == nil {
+			t.Fatalf("expected occurrence %d to be created", i+2)
+		}
+
+		next, err := s.FindTask(ctx, completion.Next.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next.ID == current.ID || next.Name != task.Name || next.Priority != task.Priority || !next.InProject(project) {
+			t.Errorf("occurrence %d does not repeat the task: %+v", i+2, next)
+		}
+		if next.Completed != nil {
+			t.Errorf("occurrence %d should be open", i+2)
+		}
+		if next.DueDate == nil || !next.DueDate.Equal(due) {
+			t.Errorf("expected occurrence %d to be due on %v found %v", i+2, due, next.DueDate)
+		}
+		if next.Recurrence == nil || next.Recurrence.Count != len(expected)-i {
+			t.Errorf("expected occurrence %d to have %d occurrences left, found %v", i+2, len(expected)-i, next.Recurrence)
+		}
+		current = next
+	}
+
+	last, err := s.CompleteTask(ctx, current.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.Next != nil {
+		t.Errorf("expected the series to end, found another occurrence due on %v", last.Next.DueDate)
+	}
+
+	// completing an occurrence twice doesn't create another one
+	if again, err := s.CompleteTask(ctx, task.ID); err != nil || again.Next != nil {
+		t.Errorf("expected no new occurrence, found %v (%v)", again.Next, err)
+	}
+	if count := mustCount(t, s); count != 3 {
+		t.Errorf("expected %d tasks found %d", 3, count)
+	}
+}
+
+func testInvalidRecurrence(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	undated := newTask(f)
+	undated.Recurrence = &togo.Recurrence{Frequency: togo.Daily}
+
+	unsupported := newTask(f)
+	unsupported.AddDueDate(daysFromNow(1))
+	unsupported.Recurrence = &togo.Recurrence{Frequency: togo.Yearly, ByDay: []togo.RecurringDay{{Weekday: time.Monday}}}
+
+	for _, task := range []togo.Task{undated, unsupported} {
+		if err := s.AddOrUpdateTask(ctx, task); !errors.Is(err, store.ErrInvalid) {
+			t.Errorf("expected %v found %v", store.ErrInvalid, err)
+		}
+	}
+	if count := mustCount(t, s); count != 0 {
+		t.Errorf("invalid tasks were saved, found %d tasks", count)
+	}
+}
+
+func testTransitionTask(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+	task := newTask(f)
+	mustAdd(t, s, task)
+
+	var want []togo.Transition
+	from := togo.Open
+	for _, to := range []togo.Status{togo.InProgress, togo.Waiting, togo.Completed, togo.Open, togo.Cancelled} {
+		clock.Advance(time.Hour)
+		completion, err := s.TransitionTask(ctx, task.ID, to)
+		if err != nil {
+			t.Fatalf("moving from %v to %v: %v", from, to, err)
+		}
+		if completion.Task.Status != to {
+			t.Errorf("expected the task to be %v found %v", to, completion.Task.Status)
+		}
+		want = append(want, togo.Transition{From: from, To: to, At: clock.Now()})
+		from = to
+	}
+
+	found := mustFind(t, s, task.ID)
+	if found.Status != togo.Cancelled || found.Completed != nil {
+		t.Errorf("expected the task to be cancelled, found %v completed at %v", found.Status, found.Completed)
+	}
+	expectTransitions(t, found.Transitions, want)
+
+	// moving to the same status changes nothing
+	clock.Advance(time.Hour)
+	if _, err := s.TransitionTask(ctx, task.ID, togo.Cancelled); err != nil {
+		t.Fatal(err)
+	}
+	expectTransitions(t, mustFind(t, s, task.ID).Transitions, want)
+
+	if _, err := s.TransitionTask(ctx, task.ID, togo.InProgress); !errors.Is(err, store.ErrConflict) {
+		t.Errorf("expected an illegal transition to be a conflict, found %v", err)
+	}
+	if _, err := s.CompleteTask(ctx, task.ID); !errors.Is(err, store.ErrConflict) {
+		t.Errorf("expected completing a cancelled task to be a conflict, found %v", err)
+	}
+	if _, err := s.TransitionTask(ctx, task.ID, togo.Status(42)); !errors.Is(err, store.ErrInvalid) {
+		t.Errorf("expected %v found %v", store.ErrInvalid, err)
+	}
+	if _, err := s.TransitionTask(ctx, uuid.New(), togo.InProgress); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected %v found %v", store.ErrNotFound, err)
+	}
+	expectTransitions(t, mustFind(t, s, task.ID).Transitions, want)
+}
+
+func testReopenTask(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+	task := newTask(f)
+	mustAdd(t, s, task)
+
+	// reopening an open task changes nothing
+	reopened, err := s.ReopenTask(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Status != togo.Open || len(reopened.Transitions) != 0 {
+		t.Errorf("expected the task to be left open, found %v with %d transitions", reopened.Status, len(reopened.Transitions))
+	}
+
+	clock.Advance(time.Hour)
+	completedAt := clock.Now()
+	if _, err := s.CompleteTask(store.WithActor(ctx, "alice"), task.ID); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Hour)
+	reopenedAt := clock.Now()
+	reopened, err = s.ReopenTask(store.WithActor(ctx, "bob"), task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Status != togo.Open || reopened.Completed != nil || reopened.IsCompleted() {
+		t.Errorf("expected the task to be reopened, found %v completed at %v", reopened.Status, reopened.Completed)
+	}
+
+	found := mustFind(t, s, task.ID)
+	if found.Completed != nil {
+		t.Errorf("expected the completion time to be cleared, found %v", found.Completed)
+	}
+	expectTransitions(t, found.CompletionHistory(), []togo.Transition{
+		{From: togo.Open, To: togo.Completed, At: completedAt, By: "alice"},
+		{From: togo.Completed, To: togo.Open, At: reopenedAt, By: "bob"},
+	})
+
+	if _, err := s.ReopenTask(ctx, uuid.New()); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected %v found %v", store.ErrNotFound, err)
+	}
+}
+
+func testReopenRecurringTask(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	recurrence, err := togo.ParseRecurrence("FREQ=DAILY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := newTask(f)
+	task.AddDueDate(daysFromNow(0))
+	task.Recurrence = &recurrence
+	mustAdd(t, s, task)
+
+	completion, err := s.CompleteTask(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if completion.Next == nil {
+		t.Fatal("expected the next occurrence to be created")
+	}
+
+	// closed by mistake, reopened and completed again
+	if _, err := s.ReopenTask(ctx, task.ID); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Hour)
+	completion, err = s.CompleteTask(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if completion.Next != nil {
+		t.Errorf("expected no other occurrence, found one due on %v", completion.Next.DueDate)
+	}
+	if count := mustCount(t, s); count != 2 {
+		t.Errorf("expected %d tasks found %d", 2, count)
+	}
+	found := mustFind(t, s, task.ID)
+	if history := found.CompletionHistory(); len(history) != 3 {
+		t.Errorf("expected 3 entries in the completion history found %d", len(history))
+	}
+}
+
+func expectTransitions(t *testing.T, got, want []togo.Transition) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d transitions found %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].From != want[i].From || got[i].To != want[i].To || !got[i].At.Equal(want[i].At) || got[i].By != want[i].By {
+			t.Errorf("expected transition %d to be %+v found %+v", i+1, want[i], got[i])
+		}
+	}
+}
+
+func testStatusRoundTrip(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	blocked := newTask(f)
+	// a status set without its history
+	waiting := newTask(f)
+	waiting.Status = togo.Waiting
+	mustAdd(t, s, blocked, waiting)
+
+	if _, err := s.TransitionTask(store.WithActor(ctx, "alice"), blocked.ID, togo.InProgress); err != nil {
+		t.Fatal(err)
+	}
+	if err := blocked.TransitionBy(togo.InProgress, clock.Now(), "alice"); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Hour)
+	if _, err := s.TransitionTask(ctx, blocked.ID, togo.Blocked); err != nil {
+		t.Fatal(err)
+	}
+	if err := blocked.TransitionAt(togo.Blocked, clock.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, task := range []togo.Task{blocked, waiting} {
+		found := mustFind(t, s, task.ID)
+		if found.Status != task.Status {
+			t.Errorf("expected %q to be %v found %v", task.Name, task.Status, found.Status)
+		}
+		expectTransitions(t, found.Transitions, task.Transitions)
+	}
+}
+
+func testInvalidStatus(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+	now := clock.Now()
+
+	unknown := newTask(f)
+	unknown.Status = togo.Status(42)
+	withoutCompletion := newTask(f)
+	withoutCompletion.Status = togo.Completed
+	openButCompleted := newTask(f)
+	openButCompleted.Completed = &now
+	illegal := newTask(f)
+	illegal.Status = togo.InProgress
+	illegal.Transitions = []togo.Transition{{From: togo.Cancelled, To: togo.InProgress, At: now}}
+
+	for _, task := range []togo.Task{unknown, withoutCompletion, openButCompleted, illegal} {
+		if err := s.AddOrUpdateTask(ctx, task); !errors.Is(err, store.ErrInvalid) {
+			t.Errorf("expected %v found %v", store.ErrInvalid, err)
+		}
+	}
+	if count := mustCount(t, s); count != 0 {
+		t.Errorf("invalid tasks were saved, found %d tasks", count)
+	}
+
+	if _, err := s.QueryTasks(ctx, store.Query{}.WhereStatus(togo.Status(42))); !errors.Is(err, store.ErrInvalid) {
+		t.Errorf("expected %v found %v", store.ErrInvalid, err)
+	}
+}
+
+func testStatusChangesNeedTransitions(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	task := newTask(f)
+	mustAdd(t, s, task)
+	if _, err := s.TransitionTask(ctx, task.ID, togo.Cancelled); err != nil {
+		t.Fatal(err)
+	}
+
+	// cancelled tasks can't be completed, not even by saving them
+	completed := mustFind(t, s, task.ID)
+	completed.Status = togo.Completed
+	completed.Completed = &completed.Created
+	completed.Transitions = nil
+	if err := s.AddOrUpdateTask(ctx, completed); !errors.Is(err, store.ErrConflict) {
+		t.Errorf("expected %v found %v", store.ErrConflict, err)
+	}
+
+	// nor reopened
+	reopened := mustFind(t, s, task.ID)
+	if err := reopened.ReopenAt(clock.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddOrUpdateTask(ctx, reopened); !errors.Is(err, store.ErrConflict) {
+		t.Errorf("expected %v found %v", store.ErrConflict, err)
+	}
+
+	if found := mustFind(t, s, task.ID); found.Status != togo.Cancelled {
+		t.Errorf("expected %v found %v", togo.Cancelled, found.Status)
+	}
+
+	// everything else can still be saved
+	renamed := mustFind(t, s, task.ID)
+	renamed.Name = "renamed"
+	mustAdd(t, s, renamed)
+	if found := mustFind(t, s, task.ID); found.Name != renamed.Name || found.Status != togo.Cancelled {
+		t.Errorf("expected %q to stay cancelled found %q %v", renamed.Name, found.Name, found.Status)
+	}
+}
+
+func testSavingKeepsTheHistory(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	task := newTask(f)
+	mustAdd(t, s, task)
+	if _, err := s.CompleteTask(store.WithActor(ctx, "alice"), task.ID); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Hour)
+	if _, err := s.ReopenTask(store.WithActor(ctx, "bob"), task.ID); err != nil {
+		t.Fatal(err)
+	}
+	history := mustFind(t, s, task.ID).Transitions
+
+	// a copy read before the task was completed is still open
+	stale := task
+	stale.Name = "renamed"
+	mustAdd(t, s, stale)
+
+	found := mustFind(t, s, task.ID)
+	if found.Name != stale.Name {
+		t.Errorf("expected %q found %q", stale.Name, found.Name)
+	}
+	if found.Status != togo.Open || found.Completed != nil {
+		t.Errorf("expected an open task found %v completed at %v", found.Status, found.Completed)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 transitions found %d", len(history))
+	}
+	expectTransitions(t, found.Transitions, history)
+}
+
+func testQueryTasksByStatus(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	inStatus := func(statuses ...togo.Status) togo.Task {
+		task := newTask(f)
+		for _, status := range statuses {
+			clock.Advance(time.Minute)
+			if err := task.TransitionAt(status, clock.Now()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return task
+	}
+	open := inStatus()
+	reopened := inStatus(togo.Completed, togo.Open)
+	inProgress := inStatus(togo.InProgress)
+	blocked := inStatus(togo.InProgress, togo.Blocked)
+	completed := inStatus(togo.Completed)
+	cancelled := inStatus(togo.Cancelled)
+	mustAdd(t, s, open, reopened, inProgress, blocked, completed, cancelled)
+
+	testCases := []struct {
+		query store.Query
+		want  []togo.Task
+	}{
+		{store.Query{}.WhereStatus(togo.Open), []togo.Task{open, reopened}},
+		{store.Query{}.WhereStatus(togo.InProgress, togo.Blocked), []togo.Task{inProgress, blocked}},
+		{store.Query{}.WhereStatus(togo.Waiting), nil},
+		{store.Query{}.WhereStatus(togo.Completed, togo.Cancelled), []togo.Task{completed, cancelled}},
+		{store.Query{}.WhereStatus(togo.Open, togo.Completed).WhereCompleted(false), []togo.Task{open, reopened}},
+		{store.Query{}.WhereCompleted(true), []togo.Task{completed, cancelled}},
+		{store.Query{}.WhereCompleted(false), []togo.Task{open, reopened, inProgress, blocked}},
+	}
+
+	for _, testCase := range testCases {
+		found, err := s.QueryTasks(ctx, testCase.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectNames(t, found, testCase.want...)
+	}
+}
+
+func testFindMissingTask(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+	mustAdd(t, s, newTask(f), newTask(f))
+
+	_, err := s.FindTask(ctx, uuid.New())
+	if !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected %v found %v", store.ErrNotFound, err)
+	}
+}
+
+func testRemoveMissingTask(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+	mustAdd(t, s, newTask(f))
+
+	err := s.RemoveTask(ctx, uuid.New())
+	if !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected %v found %v", store.ErrNotFound, err)
+	}
+	if count := mustCount(t, s); count != 1 {
+		t.Errorf("expected %d tasks found %d", 1, count)
+	}
+}
+
+func testInvalidTask(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	invalid := []togo.Task{
+		togo.NewTask("", f.Lorem().Sentence(3)),
+		togo.NewTask(strings.Repeat("x", store.MaxNameLength+1), f.Lorem().Sentence(3)),
+	}
+
+	for _, task := range invalid {
+		err := s.AddOrUpdateTask(ctx, task)
+		if !errors.Is(err, store.ErrInvalid) {
+			t.Errorf("expected %v found %v", store.ErrInvalid, err)
+		}
+	}
+
+	if count := mustCount(t, s); count != 0 {
+		t.Errorf("invalid tasks were saved, found %d tasks", count)
+	}
+}
+
+func testProjectRoundTrip(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	project := newProject(f)
+	mustAddProject(t, s, project, newProject(f))
+
+	found, err := s.FindProject(ctx, project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.ID != project.ID || found.Name != project.Name || found.Description != project.Description {
+		t.Error("found project wasn't the same as original project")
+	}
+	if !found.Created.Truncate(time.Millisecond).Equal(project.Created.Truncate(time.Millisecond)) {
+		t.Error("creation dates do not match")
+	}
+
+	originalName := project.Name
+	project.Name = f.UUID().V4()
+	project.Description = f.Lorem().Sentence(3)
+	mustAddProject(t, s, project)
+
+	found, err = s.FindProjectByName(ctx, project.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.ID != project.ID || found.Description != project.Description {
+		t.Error("project was not updated")
+	}
+
+	if _, err := s.FindProjectByName(ctx, originalName); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected %v found %v", store.ErrNotFound, err)
+	}
+
+	all, err := s.AllProjects(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected %d projects found %d", 2, len(all))
+	}
+	if all[0].Name > all[1].Name {
+		t.Error("projects are not ordered by name")
+	}
+}
+
+func testProjectNamesAreUnique(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	project := newProject(f)
+	other := newProject(f)
+	mustAddProject(t, s, project, other)
+
+	duplicate := togo.NewProject(project.Name, f.Lorem().Sentence(3))
+	if err := s.AddOrUpdateProject(ctx, duplicate); !errors.Is(err, store.ErrConflict) {
+		t.Errorf("expected %v found %v", store.ErrConflict, err)
+	}
+
+	other.Name = project.Name
+	if err := s.AddOrUpdateProject(ctx, other); !errors.Is(err, store.ErrConflict) {
+		t.Errorf("renaming onto an existing project: expected %v found %v", store.ErrConflict, err)
+	}
+
+	found, err := s.FindProjectByName(ctx, project.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.ID != project.ID {
+		t.Error("conflicting project replaced the original")
+	}
+}
+
+func testInvalidProject(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	invalid := []togo.Project{
+		togo.NewProject("", f.Lorem().Sentence(3)),
+		togo.NewProject(strings.Repeat("x", store.MaxNameLength+1), f.Lorem().Sentence(3)),
+	}
+
+	for _, project := range invalid {
+		if err := s.AddOrUpdateProject(ctx, project); !errors.Is(err, store.ErrInvalid) {
+			t.Errorf("expected %v found %v", store.ErrInvalid, err)
+		}
+	}
+}
+
+func testMissingProject(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+	mustAddProject(t, s, newProject(f))
+
+	if _, err := s.FindProject(ctx, uuid.New()); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("FindProject: expected %v found %v", store.ErrNotFound, err)
+	}
+	if _, err := s.FindProjectByName(ctx, f.UUID().V4()); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("FindProjectByName: expected %v found %v", store.ErrNotFound, err)
+	}
+	if _, err := s.TasksInProject(ctx, uuid.New()); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("TasksInProject: expected %v found %v", store.ErrNotFound, err)
+	}
+	if err := s.RemoveProject(ctx, uuid.New()); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("RemoveProject: expected %v found %v", store.ErrNotFound, err)
+	}
+}
+
+func testTasksInProject(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	project := newProject(f)
+	other := newProject(f)
+	empty := newProject(f)
+	mustAddProject(t, s, project, other, empty)
+
+	var inProject []togo.Task
+	for i := 0; i < 3; i++ {
+		task := newTask(f)
+		task.AddToProject(project)
+		inProject = append(inProject, task)
+	}
+	mustAdd(t, s, inProject...)
+
+	moved := newTask(f)
+	moved.AddToProject(project)
+	mustAdd(t, s, moved)
+	moved.AddToProject(other)
+	mustAdd(t, s, moved, newTask(f))
+
+	found, err := s.TasksInProject(ctx, project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found, inProject...)
+	for _, task := range found {
+		if !task.InProject(project) {
+			t.Errorf("task %q does not reference its project", task.Name)
+		}
+	}
+
+	found, err = s.TasksInProject(ctx, other.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found, moved)
+
+	found, err = s.TasksInProject(ctx, empty.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNames(t, found)
+}
+
+func testTaskInUnknownProject(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	task := newTask(f)
+	task.AddToProject(newProject(f))
+
+	if err := s.AddOrUpdateTask(ctx, task); !errors.Is(err, store.ErrInvalid) {
+		t.Errorf("expected %v found %v", store.ErrInvalid, err)
+	}
+	if count := mustCount(t, s); count != 0 {
+		t.Errorf("task in an unknown project was saved, found %d tasks", count)
+	}
+}
+
+func testRemoveProjectKeepsTasks(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	ctx := context.Background()
+	f := faker.New()
+
+	project := newProject(f)
+	mustAddProject(t, s, project)
+
+	task := newTask(f)
+	task.AddToProject(project)
+	mustAdd(t, s, task)
+
+	if err := s.RemoveProject(ctx, project.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.FindProject(ctx, project.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected %v found %v", store.ErrNotFound, err)
+	}
+
+	found, err := s.FindTask(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.ProjectID != nil {
+		t.Error("task still references the removed project")
+	}
+
+	// the name is free to use again
+	mustAddProject(t, s, togo.NewProject(project.Name, project.Description))
+}
+
+func testCanceledContext(t *testing.T, s store.Store, clock *togo.FakeClock) {
+	f := faker.New()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	task := newTask(f)
+	task.AddDueDate(daysFromNow(-1))
+	mustAdd(t, s, task, newTask(f))
+	cancel()
+
+	expectCanceled := func(op string, err error) {
+		t.Helper()
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: expected %v found %v", op, context.Canceled, err)
+		}
+	}
+
+	expectCanceled("AddOrUpdateTask", s.AddOrUpdateTask(ctx, newTask(f)))
+	expectCanceled("RemoveTask", s.RemoveTask(ctx, task.ID))
+	_, err := s.FindTask(ctx, task.ID)
+	expectCanceled("FindTask", err)
+	_, err = s.FindTasksByName(ctx, task.Name)
+	expectCanceled("FindTasksByName", err)
+	_, err = s.FindByDueDate(ctx, task.DueOn(), nil)
+	expectCanceled("FindByDueDate", err)
+	_, err = s.FindDueBetween(ctx, *task.DueOn(), *task.DueOn(), nil)
+	expectCanceled("FindDueBetween", err)
+	// nothing is due in this range, so only the context can fail the lookup
+	_, err = s.FindDueBetween(ctx, daysFromNow(100), daysFromNow(101), nil)
+	expectCanceled("FindDueBetween", err)
+	_, err = s.OverdueTasks(ctx, nil)
+	expectCanceled("OverdueTasks", err)
+	_, err = s.Upcoming(ctx, store.UpcomingWindow{Days: 7})
+	expectCanceled("Upcoming", err)
+	_, err = s.Count(ctx)
+	expectCanceled("Count", err)
+	_, err = s.All(ctx)
+	expectCanceled("All", err)
+	_, err = s.ListTasks(ctx, store.Page{})
+	expectCanceled("ListTasks", err)
+	_, err = s.QueryTasks(ctx, store.Query{})
+	expectCanceled("QueryTasks", err)
+	_, err = s.Subtasks(ctx, task.ID)
+	expectCanceled("Subtasks", err)
+	expectCanceled("MoveTask", s.MoveTask(ctx, task.ID, nil))
+	expectCanceled("RemoveTaskWith", s.RemoveTaskWith(ctx, task.ID, store.RemoveSubtasks))
+	_, err = s.CompleteTask(ctx, task.ID)
+	expectCanceled("CompleteTask", err)
+	_, err = s.TransitionTask(ctx, task.ID, togo.InProgress)
+	expectCanceled("TransitionTask", err)
+	_, err = s.ReopenTask(ctx, task.ID)
+	expectCanceled("ReopenTask", err)
+
+	project := newProject(f)
+	expectCanceled("AddOrUpdateProject", s.AddOrUpdateProject(ctx, project))
+	expectCanceled("RemoveProject", s.RemoveProject(ctx, project.ID))
+	_, err = s.FindProject(ctx, project.ID)
+	expectCanceled("FindProject", err)
+	_, err = s.FindProjectByName(ctx, project.Name)
+	expectCanceled("FindProjectByName", err)
+	_, err = s.AllProjects(ctx)
+	expectCanceled("AllProjects", err)
+	_, err = s.TasksInProject(ctx, project.ID)
+	expectCanceled("TasksInProject", err)
+
+	if count := mustCount(t, s); count != 2 {
+		t.Errorf("canceled operations changed the store, expected %d tasks found %d", 2, count)
+	}
+}
@@ -1,6 +1,8 @@
 package togo
 
 import (
+	"fmt"
+	"github.com/google/uuid"
 	"time"
 )
 
@@ -14,46 +16,246 @@ const (
 )
 
 type Task struct {
+	ID          uuid.UUID
 	Name        string
 	Description string
 	Priority    Priority
-	Created     time.Time
-	Completed   *time.Time
-	DueDate     *time.Time
+	ProjectID   *uuid.UUID
+	// ParentID is the task this task is a subtask of, nil for top-level tasks.
+	ParentID  *uuid.UUID
+	Created   time.Time
+	Completed *time.Time
+	// DueDate is the moment the task is due, in the task's time zone: its time
+	// of day when HasDueTime is set, otherwise the midnight that starts the day
+	// it is due on.
+	DueDate    *time.Time
+	HasDueTime bool
+	// Recurrence repeats the task from its due date, nil for one-off tasks.
+	Recurrence *Recurrence
+	Status     Status
+	// Transitions records every change of status, oldest first.
+	Transitions []Transition
 }
 
+// NewTask creates a task with a newly generated ID. Names are not unique, the
+// ID is the only way to refer to a specific task.
 func NewTask(name, description string) Task {
-	return Task{Name: name, Description: description, Created: time.Now()}
+	return NewTaskAt(SystemClock.Now(), name, description)
 }
 
+// NewTaskAt creates a task like NewTask, created at created.
+func NewTaskAt(created time.Time, name, description string) Task {
+	return Task{ID: uuid.New(), Name: name, Description: description, Created: created}
+}
+
+// IsCompleted reports whether t is Completed, whatever its completion time.
 func (t *Task) IsCompleted() bool {
-	return t.Completed != nil && t.Completed.Before(time.Now())
+	return t.Status == Completed
+}
+
+// Overdue reports whether t is overdue in loc, UTC when loc is nil. A task
+// with a due time is overdue once that time has passed; a task due on a day is
+// overdue once that day is over, starting from the first day after it in loc.
+func (t *Task) Overdue(loc *time.Location) bool {
+	return t.OverdueAt(SystemClock.Now().In(orUTC(loc)))
+}
+
+// OverdueAt reports whether t is overdue at now, taking the day it is in now's
+// location as today.
+func (t *Task) OverdueAt(now time.Time) bool {
+	if t.DueDate == nil {
+		return false
+	}
+	if t.HasDueTime {
+		return t.DueDate.Before(now)
+	}
+	return t.DueDate.Before(StartOfDay(now, now.Location()))
 }
 
-func (t *Task) Overdue() bool {
-	return t.DueDate != nil && t.DueDate.Before(time.Now())
+// Complete moves t to Completed, see TransitionAt.
+func (t *Task) Complete() error {
+	return t.CompleteAt(SystemClock.Now())
 }
 
-func (t *Task) Complete() {
-	completionTime := time.Now()
-	t.Completed = &completionTime
+// CompleteAt marks t as completed at now.
+func (t *Task) CompleteAt(now time.Time) error {
+	return t.TransitionAt(Completed, now)
+}
+
+// NextOccurrence returns the occurrence of a recurring task that follows t: a
+// new, open task with the same details, due on the next day selected by the
+// recurrence. It returns false when t doesn't recur, has no due date, or is
+// the last occurrence.
+func (t *Task) NextOccurrence() (Task, bool) {
+	return t.NextOccurrenceAt(SystemClock.Now())
+}
+
+// NextOccurrenceAt returns the occurrence that follows t like NextOccurrence,
+// created at created.
+func (t *Task) NextOccurrenceAt(created time.Time) (Task, bool) {
+	if t.Recurrence == nil || t.DueDate == nil {
+		return Task{}, false
+	}
+	due, ok := t.Recurrence.Next(*t.DueDate)
+	if !ok {
+		return Task{}, false
+	}
+
+	next := NewTaskAt(created, t.Name, t.Description)
+	next.Priority = t.Priority
+	next.ProjectID = t.ProjectID
+	next.ParentID = t.ParentID
+	next.DueDate = &due
+	next.HasDueTime = t.HasDueTime
+	recurrence := t.Recurrence.following()
+	next.Recurrence = &recurrence
+	return next, true
 }
 
 func (t *Task) CompletionDate() *time.Time {
 	return t.Completed
 }
 
-// AddDueDate strips off the time component and stores the result as the due date
+// AddDueDate makes t due on the day of due, in due's location. The time of day
+// is dropped. A date in time.Local keeps its day but is moved to UTC, since
+// "Local" means something different to every machine reading the task.
 func (t *Task) AddDueDate(due time.Time) {
-	yyyy, mm, dd := due.Date()
-	newDate := time.Date(yyyy, mm, dd, 0, 0, 0, 0, time.UTC)
+	newDate := StartOfDay(due, dueLocation(due))
 	t.DueDate = &newDate
+	t.HasDueTime = false
+}
+
+// AddDueTime makes t due at due, to the minute, in due's location. A time in
+// time.Local is kept as the same instant in UTC.
+func (t *Task) AddDueTime(due time.Time) {
+	newTime := due.Truncate(time.Minute).In(dueLocation(due))
+	t.DueDate = &newTime
+	t.HasDueTime = true
+}
+
+// dueLocation is the location a due date set from due is kept in: due's own,
+// unless that is time.Local.
+func dueLocation(due time.Time) *time.Location {
+	if due.Location() == time.Local {
+		return time.UTC
+	}
+	return due.Location()
 }
 
 func (t *Task) DueOn() *time.Time {
 	return t.DueDate
 }
 
+// DueZone returns the time zone of t's due date, nil when it has none.
+func (t *Task) DueZone() *time.Location {
+	if t.DueDate == nil {
+		return nil
+	}
+	return t.DueDate.Location()
+}
+
+// StartOfDay returns the midnight that starts day's date in loc, UTC when loc
+// is nil. The date is read in day's own location, so a date parsed as
+// midnight UTC names the same day in every location.
+func StartOfDay(day time.Time, loc *time.Location) time.Time {
+	yyyy, mm, dd := day.Date()
+	return time.Date(yyyy, mm, dd, 0, 0, 0, 0, orUTC(loc))
+}
+
+// Today returns the midnight that started the current day in loc, UTC when
+// loc is nil.
+func Today(loc *time.Location) time.Time {
+	return TodayAt(SystemClock.Now(), loc)
+}
+
+// TodayAt returns the midnight that started the day of now in loc, UTC when
+// loc is nil.
+func TodayAt(now time.Time, loc *time.Location) time.Time {
+	loc = orUTC(loc)
+	return StartOfDay(now.In(loc), loc)
+}
+
+// LoadZone returns the IANA time zone with the given name. Unlike
+// time.LoadLocation it rejects "" and "Local", whose meaning depends on the
+// machine reading them.
+func LoadZone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("%q is not an IANA time zone", name)
+	}
+	return time.LoadLocation(name)
+}
+
+func orUTC(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func (t *Task) AddToProject(p Project) {
+	id := p.ID
+	t.ProjectID = &id
+}
+
+func (t *Task) RemoveFromProject() {
+	t.ProjectID = nil
+}
+
+func (t *Task) InProject(p Project) bool {
+	return t.ProjectID != nil && *t.ProjectID == p.ID
+}
+
+// AddToParent makes t a subtask of parent.
+func (t *Task) AddToParent(parent Task) {
+	id := parent.ID
+	t.ParentID = &id
+}
+
+// RemoveFromParent makes t a top-level task.
+func (t *Task) RemoveFromParent() {
+	t.ParentID = nil
+}
+
+func (t *Task) IsSubtaskOf(parent Task) bool {
+	return t.ParentID != nil && *t.ParentID == parent.ID
+}
+
+// Progress rolls the completion of a task's subtasks up into the task.
+type Progress struct {
+	Completed int
+	Total     int
+}
+
+// RollUp counts the completed tasks among subtasks. Cancelled subtasks are
+// left out of the total: they no longer stand in the way of their parent.
+func RollUp(subtasks []Task) Progress {
+	var p Progress
+	for _, t := range subtasks {
+		switch t.Status {
+		case Cancelled:
+			continue
+		case Completed:
+			p.Completed++
+		}
+		p.Total++
+	}
+	return p
+}
+
+// Done reports whether every subtask is completed. A task without subtasks is
+// never done by its subtasks alone.
+func (p Progress) Done() bool {
+	return p.Total > 0 && p.Completed == p.Total
+}
+
+// Fraction returns the share of subtasks that are completed, from 0 to 1.
+func (p Progress) Fraction() float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return float64(p.Completed) / float64(p.Total)
+}
+
 type Tasks []Task
 
 func (ts Tasks) Len() int {
@@ -1,8 +1,10 @@
 package togo
 
 import (
+	"reflect"
 	"sort"
 	"testing"
+	"time"
 )
 
 func TestNewTaskIsNotCompleted(t *testing.T) {
@@ -32,11 +34,143 @@ func TestTasksSort(t *testing.T) {
 
 	sort.Sort(Tasks(ts))
 
-	if zero != ts[0] {
+	if !reflect.DeepEqual(zero, ts[0]) {
 		t.Error("did not sort")
 	}
 
-	if five != ts[5] {
+	if !reflect.DeepEqual(five, ts[5]) {
 		t.Error("did not sort")
 	}
 }
+
+func TestRollUp(t *testing.T) {
+	done := NewTask("done", "")
+	done.Complete()
+	open := NewTask("open", "")
+	cancelled := NewTask("cancelled", "")
+	_ = cancelled.TransitionAt(Cancelled, time.Now())
+
+	testCases := []struct {
+		subtasks []Task
+		expected Progress
+		finished bool
+		fraction float64
+	}{
+		{subtasks: nil, expected: Progress{}, finished: false, fraction: 0},
+		{subtasks: []Task{open, done}, expected: Progress{Completed: 1, Total: 2}, finished: false, fraction: 0.5},
+		{subtasks: []Task{done, done}, expected: Progress{Completed: 2, Total: 2}, finished: true, fraction: 1},
+		{subtasks: []Task{done, cancelled}, expected: Progress{Completed: 1, Total: 1}, finished: true, fraction: 1},
+		{subtasks: []Task{cancelled}, expected: Progress{}, finished: false, fraction: 0},
+	}
+
+	for _, testCase := range testCases {
+		progress := RollUp(testCase.subtasks)
+		if progress != testCase.expected {
+			t.Errorf("expected %+v found %+v", testCase.expected, progress)
+		}
+		if progress.Done() != testCase.finished {
+			t.Errorf("%+v: expected done to be %v", progress, testCase.finished)
+		}
+		if progress.Fraction() != testCase.fraction {
+			t.Errorf("%+v: expected fraction %v found %v", progress, testCase.fraction, progress.Fraction())
+		}
+	}
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestDueDatesKeepTheirZone(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	task := NewTask("name", "description")
+	task.AddDueDate(time.Date(2030, time.January, 8, 23, 30, 0, 0, tokyo))
+	if !task.DueDate.Equal(time.Date(2030, time.January, 8, 0, 0, 0, 0, tokyo)) || task.HasDueTime {
+		t.Errorf("expected midnight in Tokyo found %v", task.DueDate)
+	}
+	if task.DueZone() != tokyo {
+		t.Errorf("expected %v found %v", tokyo, task.DueZone())
+	}
+
+	task.AddDueTime(time.Date(2030, time.January, 8, 9, 15, 42, 0, tokyo))
+	if !task.DueDate.Equal(time.Date(2030, time.January, 8, 9, 15, 0, 0, tokyo)) || !task.HasDueTime {
+		t.Errorf("expected 9:15 in Tokyo found %v", task.DueDate)
+	}
+}
+
+func TestLocalDueDatesAreKeptInUTC(t *testing.T) {
+	task := NewTask("due locally", "")
+	local := time.Date(2030, time.January, 8, 23, 30, 0, 0, time.Local)
+
+	task.AddDueDate(local)
+	if expected := time.Date(2030, time.January, 8, 0, 0, 0, 0, time.UTC); !task.DueDate.Equal(expected) || task.DueDate.Location() != time.UTC {
+		t.Errorf("expected %v found %v", expected, task.DueDate)
+	}
+
+	task.AddDueTime(local)
+	if !task.DueDate.Equal(local) || task.DueDate.Location() != time.UTC {
+		t.Errorf("expected %v in UTC found %v", local, task.DueDate)
+	}
+}
+
+func TestOverdueAt(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	losAngeles := mustLoadLocation(t, "America/Los_Angeles")
+	// the same moment is the 8th in Tokyo and still the 7th in UTC and Los Angeles
+	now := time.Date(2030, time.January, 8, 2, 0, 0, 0, tokyo)
+
+	dueOn7th := NewTask("due on the 7th", "")
+	dueOn7th.AddDueDate(time.Date(2030, time.January, 7, 0, 0, 0, 0, time.UTC))
+	dueAt1am := NewTask("due at 1am", "")
+	dueAt1am.AddDueTime(time.Date(2030, time.January, 8, 1, 0, 0, 0, tokyo))
+	dueAt3am := NewTask("due at 3am", "")
+	dueAt3am.AddDueTime(time.Date(2030, time.January, 8, 3, 0, 0, 0, tokyo))
+	undated := NewTask("undated", "")
+
+	testCases := []struct {
+		task     Task
+		now      time.Time
+		expected bool
+	}{
+		{dueOn7th, now, true},
+		{dueOn7th, now.UTC(), false},
+		{dueAt1am, now, true},
+		{dueAt1am, now.In(losAngeles), true},
+		{dueAt3am, now, false},
+		{undated, now, false},
+	}
+
+	for _, testCase := range testCases {
+		if overdue := testCase.task.OverdueAt(testCase.now); overdue != testCase.expected {
+			t.Errorf("%q at %v: expected %v found %v", testCase.task.Name, testCase.now, testCase.expected, overdue)
+		}
+	}
+}
+
+func TestCompletionIsAtTheGivenTime(t *testing.T) {
+	now := time.Date(2030, time.March, 14, 9, 30, 0, 0, time.UTC)
+	task := NewTaskAt(now, "name", "description")
+	if !task.Created.Equal(now) {
+		t.Errorf("expected the task to be created at %v found %v", now, task.Created)
+	}
+
+	// completion times can be in the future, the clock may be set back
+	if err := task.CompleteAt(now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if !task.IsCompleted() {
+		t.Error("expected the task to be completed")
+	}
+	if err := task.ReopenAt(now.Add(2 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if task.IsCompleted() || task.Completed != nil {
+		t.Error("expected the reopened task not to be completed")
+	}
+}